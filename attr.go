@@ -0,0 +1,148 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// HasAttributeSubset returns true if every attribute in attrs appears
+// in n.Attr with the same Namespace, Key and Val fields. It returns
+// false if n is nil.
+func HasAttributeSubset(n *html.Node, attrs []html.Attribute) bool {
+	if n == nil {
+		return false
+	}
+	am := map[html.Attribute]struct{}{}
+	for _, a := range n.Attr {
+		am[a] = struct{}{}
+	}
+	for _, a := range attrs {
+		if _, ok := am[a]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// AttributeMap returns the attributes of n as a map from Key to Val,
+// ignoring the Namespace fields. Where a key appears more than once
+// the first value is kept, agreeing with Attr. It returns nil if n is
+// nil.
+func AttributeMap(n *html.Node) map[string]string {
+	if n == nil {
+		return nil
+	}
+	m := make(map[string]string, len(n.Attr))
+	for _, a := range n.Attr {
+		if _, ok := m[a.Key]; !ok {
+			m[a.Key] = a.Val
+		}
+	}
+	return m
+}
+
+// AttributeMapNS is like AttributeMap but returns a map from
+// Namespace to a map from Key to Val, agreeing with AttrNS.
+func AttributeMapNS(n *html.Node) map[string]map[string]string {
+	if n == nil {
+		return nil
+	}
+	m := map[string]map[string]string{}
+	for _, a := range n.Attr {
+		if m[a.Namespace] == nil {
+			m[a.Namespace] = map[string]string{}
+		}
+		if _, ok := m[a.Namespace][a.Key]; !ok {
+			m[a.Namespace][a.Key] = a.Val
+		}
+	}
+	return m
+}
+
+// HasClass reports whether the class attribute of n contains cls as
+// one of its whitespace separated tokens. So a node with
+// class="foo-bar baz" has the classes "foo-bar" and "baz", but not
+// "foo".
+func HasClass(n *html.Node, cls string) bool {
+	v, ok := Attr(n, "class")
+	if !ok {
+		return false
+	}
+	for _, c := range strings.Fields(v) {
+		if c == cls {
+			return true
+		}
+	}
+	return false
+}
+
+// AttrDefault returns the value that Attr(n,key) returns if n has
+// such an attribute, and defaultVal otherwise.
+func AttrDefault(n *html.Node, key, defaultVal string) string {
+	if v, ok := Attr(n, key); ok {
+		return v
+	}
+	return defaultVal
+}
+
+// AttrInt returns the value of Attr(n,key) parsed as a decimal integer
+// with strconv.Atoi, after removing leading and trailing whitespace.
+// If there is no such attribute, or its value does not parse, it
+// returns defaultVal.
+func AttrInt(n *html.Node, key string, defaultVal int) int {
+	v, ok := Attr(n, key)
+	if !ok {
+		return defaultVal
+	}
+	i, err := strconv.Atoi(strings.TrimSpace(v))
+	if err != nil {
+		return defaultVal
+	}
+	return i
+}
+
+// AttrFloat64 is like AttrInt but parses the value as a floating
+// point number with strconv.ParseFloat.
+func AttrFloat64(n *html.Node, key string, defaultVal float64) float64 {
+	v, ok := Attr(n, key)
+	if !ok {
+		return defaultVal
+	}
+	f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+	if err != nil {
+		return defaultVal
+	}
+	return f
+}
+
+// AttrBool reports whether n has an attribute with the given key,
+// whatever its value. This is the meaning of HTML boolean attributes
+// such as disabled, checked and required, whose presence alone makes
+// them true.
+func AttrBool(n *html.Node, key string) bool {
+	_, ok := Attr(n, key)
+	return ok
+}
@@ -0,0 +1,274 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// The values of NodeDiff.Op.
+const (
+	DiffInsert = "insert" // New was inserted; Old is nil
+	DiffDelete = "delete" // Old was deleted; New is nil
+	DiffAttr   = "attr"   // the attributes of Old and New differ
+	DiffData   = "data"   // the Data fields of Old and New differ
+)
+
+// NodeDiff is a single difference between two trees, as returned by
+// Diff. Path locates the node concerned, in the tree of Old for
+// DiffDelete and in the tree of New otherwise. It is a CSS style path
+// such as "html > body > div:nth-child(2) > p", where :nth-child is
+// added to an element which has element siblings with the same name,
+// and non-element nodes appear as #text, #comment and so on.
+type NodeDiff struct {
+	Op       string
+	Path     string
+	Old, New *html.Node
+}
+
+// Diff compares the trees at a and b and returns the differences
+// between them in document order. Two nodes correspond if they have
+// the same Type and Namespace and, for element nodes, the same Data
+// field. Corresponding nodes are reported with DiffAttr if their
+// attributes differ (ignoring order) and with DiffData if they are
+// not element nodes and their Data fields differ. The children of
+// corresponding nodes are paired using a longest common subsequence,
+// and children left unpaired are reported with DiffDelete or
+// DiffInsert, without their descendants. If a and b themselves do not
+// correspond, Diff reports a deleted and b inserted.
+func Diff(a, b *html.Node) []NodeDiff {
+	var result []NodeDiff
+	diffNodes(&result, a, b)
+	return result
+}
+
+// diffNodes appends the differences between a and b to d.
+func diffNodes(d *[]NodeDiff, a, b *html.Node) {
+	switch {
+	case a == nil && b == nil:
+		return
+	case a == nil:
+		*d = append(*d, NodeDiff{Op: DiffInsert, Path: cssPath(b), New: b})
+		return
+	case b == nil || !correspond(a, b):
+		*d = append(*d, NodeDiff{Op: DiffDelete, Path: cssPath(a), Old: a})
+		diffNodes(d, nil, b)
+		return
+	}
+	if a.Type != html.ElementNode && a.Data != b.Data {
+		*d = append(*d, NodeDiff{Op: DiffData, Path: cssPath(b), Old: a, New: b})
+	}
+	if !sameAttrs(a, b) {
+		*d = append(*d, NodeDiff{Op: DiffAttr, Path: cssPath(b), Old: a, New: b})
+	}
+	var as, bs []*html.Node
+	for c := a.FirstChild; c != nil; c = c.NextSibling {
+		as = append(as, c)
+	}
+	for c := b.FirstChild; c != nil; c = c.NextSibling {
+		bs = append(bs, c)
+	}
+	i, j := 0, 0
+	for _, op := range lcsEdits(len(as), len(bs), func(i, j int) bool {
+		return correspond(as[i], bs[j])
+	}) {
+		switch op {
+		case editKeep:
+			diffNodes(d, as[i], bs[j])
+			i++
+			j++
+		case editDelete:
+			diffNodes(d, as[i], nil)
+			i++
+		case editInsert:
+			diffNodes(d, nil, bs[j])
+			j++
+		}
+	}
+}
+
+// The operations of an edit script returned by lcsEdits.
+const (
+	editKeep   = iota // keep the next elements of both sequences
+	editDelete        // delete the next element of the first sequence
+	editInsert        // insert the next element of the second sequence
+)
+
+// lcsEdits returns an edit script turning a sequence of length m into
+// one of length n, based on a longest common subsequence of the two.
+// eq(i,j) reports whether element i of the first sequence equals
+// element j of the second. Where there is a choice, deletions are
+// placed before insertions.
+func lcsEdits(m, n int, eq func(i, j int) bool) []int {
+	// lcs[i][j] is the length of the longest common subsequence of
+	// the first sequence from i and the second from j.
+	lcs := make([][]int, m+1)
+	for i := range lcs {
+		lcs[i] = make([]int, n+1)
+	}
+	for i := m - 1; i >= 0; i-- {
+		for j := n - 1; j >= 0; j-- {
+			switch {
+			case eq(i, j):
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			case lcs[i+1][j] >= lcs[i][j+1]:
+				lcs[i][j] = lcs[i+1][j]
+			default:
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	var ops []int
+	i, j := 0, 0
+	for i < m || j < n {
+		switch {
+		case i < m && j < n && eq(i, j):
+			ops = append(ops, editKeep)
+			i++
+			j++
+		case j == n || (i < m && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, editDelete)
+			i++
+		default:
+			ops = append(ops, editInsert)
+			j++
+		}
+	}
+	return ops
+}
+
+// correspond reports whether a and b are treated as the same node by
+// Diff.
+func correspond(a, b *html.Node) bool {
+	return a.Type == b.Type && a.Namespace == b.Namespace &&
+		(a.Type != html.ElementNode || a.Data == b.Data)
+}
+
+// sameAttrs reports whether a and b have the same attributes,
+// ignoring order.
+func sameAttrs(a, b *html.Node) bool {
+	if len(a.Attr) != len(b.Attr) {
+		return false
+	}
+	am := map[html.Attribute]int{}
+	for _, at := range a.Attr {
+		am[at]++
+	}
+	for _, at := range b.Attr {
+		if am[at] == 0 {
+			return false
+		}
+		am[at]--
+	}
+	return true
+}
+
+// cssPath returns a CSS-like path from the document root down to n,
+// with segments separated by " > ". An element's segment is its name,
+// prefixed by "namespace|" if it has a namespace and followed by
+// ":nth-child(i)" if an element sibling has the same name. Other
+// nodes give "#text", "#comment", "#doctype" or "#error". The
+// document node itself contributes no segment, so cssPath returns ""
+// for it.
+func cssPath(n *html.Node) string {
+	var segs []string
+	for ; n != nil && n.Type != html.DocumentNode; n = n.Parent {
+		var seg string
+		switch n.Type {
+		case html.ElementNode:
+			seg = n.Data
+			if n.Namespace != "" {
+				seg = n.Namespace + "|" + seg
+			}
+			i, dup := 0, false
+			for c := n; c != nil; c = c.PrevSibling {
+				if c.Type == html.ElementNode {
+					i++
+					dup = dup || (c != n && c.Data == n.Data)
+				}
+			}
+			for c := n.NextSibling; c != nil && !dup; c = c.NextSibling {
+				dup = c.Type == html.ElementNode && c.Data == n.Data
+			}
+			if dup {
+				seg += fmt.Sprintf(":nth-child(%d)", i)
+			}
+		case html.TextNode:
+			seg = "#text"
+		case html.CommentNode:
+			seg = "#comment"
+		case html.DoctypeNode:
+			seg = "#doctype"
+		default:
+			seg = "#error"
+		}
+		segs = append([]string{seg}, segs...)
+	}
+	return strings.Join(segs, " > ")
+}
+
+// PrintDiff prints the trees got and want in the format of PrintTree
+// (without colour), merged into a single listing by a line based
+// diff. Lines which appear in both trees are prefixed with two
+// spaces, lines only in got with "+ " and lines only in want with
+// "- ". It is intended for reporting test failures.
+//
+// PrintDiff returns any error it gets when writing to w.
+func PrintDiff(w io.Writer, got, want *html.Node) error {
+	var gb, wb bytes.Buffer
+	if err := PrintTree(&gb, got, false); err != nil {
+		return err
+	}
+	if err := PrintTree(&wb, want, false); err != nil {
+		return err
+	}
+	gs := strings.SplitAfter(gb.String(), "\n")
+	ws := strings.SplitAfter(wb.String(), "\n")
+	gs, ws = gs[:len(gs)-1], ws[:len(ws)-1]
+	i, j := 0, 0
+	for _, op := range lcsEdits(len(ws), len(gs), func(i, j int) bool {
+		return ws[i] == gs[j]
+	}) {
+		var line string
+		switch op {
+		case editKeep:
+			line = "  " + ws[i]
+			i++
+			j++
+		case editDelete:
+			line = "- " + ws[i]
+			i++
+		case editInsert:
+			line = "+ " + gs[j]
+			j++
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
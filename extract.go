@@ -0,0 +1,469 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Image holds the attributes of an <img> element found by
+// ExtractImages, together with the element itself. Attributes which
+// are not present on the element are left empty.
+type Image struct {
+	Src, Alt, Title string
+	Width, Height   string
+	Node            *html.Node
+}
+
+// ExtractImages returns an Image for each <img> element in the tree
+// at root, in document order. If there are no such elements it
+// returns the empty slice.
+func ExtractImages(root *html.Node) []Image {
+	var result []Image
+	for _, n := range Find(root, `<img>`) {
+		img := Image{Node: n}
+		img.Src, _ = Attr(n, "src")
+		img.Alt, _ = Attr(n, "alt")
+		img.Title, _ = Attr(n, "title")
+		img.Width, _ = Attr(n, "width")
+		img.Height, _ = Attr(n, "height")
+		result = append(result, img)
+	}
+	return result
+}
+
+// ExtractMeta returns the <meta> elements found under <head> in the
+// tree at root as a map from key to value. The key is taken from the
+// name attribute, or from the property attribute if there is no name
+// attribute, and the value is taken from the content attribute. Meta
+// elements without a key are skipped. Where a key appears more than
+// once the last value encountered is kept.
+func ExtractMeta(root *html.Node) map[string]string {
+	m := map[string]string{}
+	for _, n := range Find(root, `<meta>`) {
+		if !hasAncestor(n, "head") {
+			continue
+		}
+		key, ok := Attr(n, "name")
+		if !ok {
+			if key, ok = Attr(n, "property"); !ok {
+				continue
+			}
+		}
+		m[key], _ = Attr(n, "content")
+	}
+	return m
+}
+
+// ExtractOpenGraph returns the Open Graph properties declared by
+// <meta property="og:..." content="..."> elements in the tree at
+// root. The keys of the returned map are the property names with the
+// "og:" prefix removed, so og:title is returned under "title". Where
+// a property appears more than once the last value encountered is
+// kept.
+func ExtractOpenGraph(root *html.Node) map[string]string {
+	m := map[string]string{}
+	for _, n := range Find(root, `<meta>`) {
+		prop, _ := Attr(n, "property")
+		if !strings.HasPrefix(prop, "og:") {
+			continue
+		}
+		m[prop[len("og:"):]], _ = Attr(n, "content")
+	}
+	return m
+}
+
+// ExtractJSONLd returns the contents of each <script
+// type="application/ld+json"> element in the tree at root, in
+// document order. The text of each script is obtained with Flatten
+// and returned as raw JSON for the caller to unmarshal.
+//
+// Scripts which do not contain valid JSON are skipped rather than
+// causing the whole document to fail. An error describing each
+// skipped script, identified by its position among all <script>
+// elements under root, is joined into the returned error, which is
+// nil if every script was valid.
+func ExtractJSONLd(root *html.Node) ([]json.RawMessage, error) {
+	var result []json.RawMessage
+	var errs []error
+	for i, n := range Find(root, `<script>`) {
+		t, _ := Attr(n, "type")
+		if !strings.EqualFold(strings.TrimSpace(t), "application/ld+json") {
+			continue
+		}
+		b := []byte(strings.TrimSpace(Flatten(n)))
+		var v interface{}
+		if err := json.Unmarshal(b, &v); err != nil {
+			errs = append(errs, fmt.Errorf(
+				"htmlnode: <script> element %d: %w", i, err))
+			continue
+		}
+		result = append(result, json.RawMessage(b))
+	}
+	return result, errors.Join(errs...)
+}
+
+// Canonical returns the href attribute of the first <link> element
+// in the tree at root with canonical among its rel tokens, compared
+// without regard to case. The second return value indicates if such
+// an element was found.
+func Canonical(root *html.Node) (string, bool) {
+	for _, n := range Find(root, `<link>`) {
+		if !hasRel(n, "canonical") {
+			continue
+		}
+		if href, ok := Attr(n, "href"); ok {
+			return href, true
+		}
+	}
+	return "", false
+}
+
+// Title returns the text content of the first <title> element in the
+// tree at root, as given by Flatten, with leading and trailing
+// whitespace removed. The second return value indicates if a <title>
+// element was found.
+func Title(root *html.Node) (string, bool) {
+	ns := Find(root, `<title>`)
+	if len(ns) == 0 {
+		return "", false
+	}
+	return strings.TrimSpace(Flatten(ns[0])), true
+}
+
+// Description returns the content attribute of the first <meta
+// name="description"> element in the tree at root which has one,
+// comparing the name attribute without regard to case. If there is
+// no such element the content of the first <meta
+// property="og:description"> element which has one is returned
+// instead. The second return value indicates if either was found.
+func Description(root *html.Node) (string, bool) {
+	var og *html.Node
+	for _, n := range Find(root, `<meta>`) {
+		content, ok := Attr(n, "content")
+		if !ok {
+			continue
+		}
+		if name, _ := Attr(n, "name"); strings.EqualFold(name, "description") {
+			return content, true
+		}
+		prop, _ := Attr(n, "property")
+		if og == nil && strings.EqualFold(prop, "og:description") {
+			og = n
+		}
+	}
+	if og == nil {
+		return "", false
+	}
+	return Attr(og, "content")
+}
+
+// FeedLink describes an RSS or Atom feed advertised by a <link>
+// element, as returned by FeedLinks.
+type FeedLink struct {
+	Href, Title, Type string
+}
+
+// FeedLinks returns a FeedLink for each <link rel="alternate">
+// element in the tree at root whose type attribute is
+// application/rss+xml or application/atom+xml, in document order.
+func FeedLinks(root *html.Node) []FeedLink {
+	var result []FeedLink
+	for _, n := range Find(root, `<link>`) {
+		if !hasRel(n, "alternate") {
+			continue
+		}
+		t, _ := Attr(n, "type")
+		t = strings.ToLower(strings.TrimSpace(t))
+		if t != "application/rss+xml" && t != "application/atom+xml" {
+			continue
+		}
+		fl := FeedLink{Type: t}
+		fl.Href, _ = Attr(n, "href")
+		fl.Title, _ = Attr(n, "title")
+		result = append(result, fl)
+	}
+	return result
+}
+
+// HreflangLink describes a language alternate of a page, as returned
+// by Hreflang.
+type HreflangLink struct {
+	Href, Hreflang string
+}
+
+// Hreflang returns an HreflangLink for each <link rel="alternate">
+// element in the tree at root which has an hreflang attribute, in
+// document order.
+func Hreflang(root *html.Node) []HreflangLink {
+	var result []HreflangLink
+	for _, n := range Find(root, `<link>`) {
+		lang, ok := Attr(n, "hreflang")
+		if !ok || !hasRel(n, "alternate") {
+			continue
+		}
+		href, _ := Attr(n, "href")
+		result = append(result, HreflangLink{Href: href, Hreflang: lang})
+	}
+	return result
+}
+
+// Form describes a <form> element and its controls, as returned by
+// ExtractForms.
+type Form struct {
+	Action, Method string
+	Inputs         []Input
+	Node           *html.Node
+}
+
+// Input describes a form control: an <input>, <select> or <textarea>
+// element. Type is the type attribute of an <input> (defaulting to
+// "text"), or else the tag name of the control. Value is the default
+// value of the control: the value attribute of an <input>, the text
+// of a <textarea>, or the value of the first selected <option> of a
+// <select> (the first <option> if none is selected).
+type Input struct {
+	Name, Type, Value string
+	Node              *html.Node
+}
+
+// ExtractForms returns a Form for each <form> element in the tree at
+// root, in document order. The Inputs of each Form are its descendant
+// controls, also in document order. The Method is lower case and
+// defaults to "get" if the form has no method attribute.
+func ExtractForms(root *html.Node) []Form {
+	var result []Form
+	for _, f := range Find(root, `<form>`) {
+		form := Form{Node: f, Method: "get"}
+		form.Action, _ = Attr(f, "action")
+		if m, ok := Attr(f, "method"); ok {
+			form.Method = strings.ToLower(m)
+		}
+		for n := f; n != nil; n, _ = Next(n, f) {
+			if n.Type != html.ElementNode || n.Namespace != "" {
+				continue
+			}
+			in := Input{Node: n}
+			switch n.Data {
+			case "input":
+				in.Type = "text"
+				if t, ok := Attr(n, "type"); ok {
+					in.Type = strings.ToLower(t)
+				}
+				in.Value, _ = Attr(n, "value")
+			case "select":
+				in.Type = n.Data
+				in.Value = selectValue(n)
+			case "textarea":
+				in.Type = n.Data
+				in.Value = Flatten(n)
+			default:
+				continue
+			}
+			in.Name, _ = Attr(n, "name")
+			form.Inputs = append(form.Inputs, in)
+		}
+		result = append(result, form)
+	}
+	return result
+}
+
+// ExtractList returns the text of each <li> child of list, which must
+// be a <ul> or <ol> element, in document order. The text of an item
+// is obtained as with Flatten, except that the contents of any <ul>
+// or <ol> nested within the item are skipped, and leading and
+// trailing whitespace is removed. If list is not a <ul> or <ol>
+// element ExtractList returns an error.
+func ExtractList(list *html.Node) ([]string, error) {
+	if list == nil || list.Type != html.ElementNode ||
+		(list.Data != "ul" && list.Data != "ol") {
+		return nil, errors.New("htmlnode: ExtractList: node is not <ul> or <ol>")
+	}
+	var result []string
+	for li := list.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		var s string
+		for n := li; n != nil; {
+			if n != li && n.Type == html.ElementNode &&
+				(n.Data == "ul" || n.Data == "ol") {
+				n = skip(n, li)
+				continue
+			}
+			if n.Type == html.TextNode {
+				s += n.Data
+			}
+			n, _ = Next(n, li)
+		}
+		result = append(result, strings.TrimSpace(s))
+	}
+	return result, nil
+}
+
+// Heading is an entry in the outline of a document returned by
+// ExtractHeadings. Level is 1 for <h1> through to 6 for <h6>, and
+// Text is the Flatten text of the heading element with leading and
+// trailing whitespace removed.
+type Heading struct {
+	Level    int
+	Text     string
+	Node     *html.Node
+	Children []Heading
+}
+
+// ExtractHeadings returns the outline of the tree at root formed by
+// its <h1> to <h6> elements. Headings are visited in document order
+// and each one becomes a child of the closest preceding heading with
+// a lower level, or a top-level entry if there is none. So an <h3>
+// following an <h2> is a child of the <h2>, while an <h2> following
+// an <h3> closes the <h3> and becomes a sibling of the <h2> which
+// contains it.
+func ExtractHeadings(root *html.Node) []Heading {
+	var flat []Heading
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type != html.ElementNode || n.Namespace != "" ||
+			len(n.Data) != 2 || n.Data[0] != 'h' ||
+			n.Data[1] < '1' || n.Data[1] > '6' {
+			continue
+		}
+		flat = append(flat, Heading{
+			Level: int(n.Data[1] - '0'),
+			Text:  strings.TrimSpace(Flatten(n)),
+			Node:  n,
+		})
+	}
+	result, _ := outline(flat, 0)
+	return result
+}
+
+// outline consumes the headings at the front of hs whose level is
+// greater than level, nesting them as described in ExtractHeadings.
+// It returns the nested headings and the remainder of hs.
+func outline(hs []Heading, level int) ([]Heading, []Heading) {
+	var result []Heading
+	for len(hs) > 0 && hs[0].Level > level {
+		h := hs[0]
+		h.Children, hs = outline(hs[1:], h.Level)
+		result = append(result, h)
+	}
+	return result, hs
+}
+
+// ExtractScripts returns the text content of each inline <script>
+// element (one without a src attribute) in the tree at root, in
+// document order.
+func ExtractScripts(root *html.Node) []string {
+	var result []string
+	for _, n := range Find(root, `<script>`) {
+		if _, ok := Attr(n, "src"); !ok {
+			result = append(result, Flatten(n))
+		}
+	}
+	return result
+}
+
+// ExtractScriptSrcs returns the src attribute of each external
+// <script> element in the tree at root, in document order.
+func ExtractScriptSrcs(root *html.Node) []string {
+	var result []string
+	for _, n := range Find(root, `<script>`) {
+		if src, ok := Attr(n, "src"); ok {
+			result = append(result, src)
+		}
+	}
+	return result
+}
+
+// ExtractStyles returns the text content of each <style> element in
+// the tree at root, in document order.
+func ExtractStyles(root *html.Node) []string {
+	var result []string
+	for _, n := range Find(root, `<style>`) {
+		result = append(result, Flatten(n))
+	}
+	return result
+}
+
+// selectValue returns the default value of the <select> element n.
+func selectValue(n *html.Node) string {
+	var first *html.Node
+	for o := n; o != nil; o, _ = Next(o, n) {
+		if o.Type != html.ElementNode || o.Data != "option" {
+			continue
+		}
+		if _, ok := Attr(o, "selected"); ok {
+			first = o
+			break
+		}
+		if first == nil {
+			first = o
+		}
+	}
+	if first == nil {
+		return ""
+	}
+	if v, ok := Attr(first, "value"); ok {
+		return v
+	}
+	return Flatten(first)
+}
+
+// hasRel reports whether the space separated rel attribute of n
+// contains the link type rel, compared without regard to case.
+func hasRel(n *html.Node, rel string) bool {
+	v, _ := Attr(n, "rel")
+	for _, r := range strings.Fields(v) {
+		if strings.EqualFold(r, rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// skip returns the node following the subtree at n in a depth first
+// traversal of the tree at root, or nil if there is none.
+func skip(n, root *html.Node) *html.Node {
+	for n != nil && n != root {
+		if n.NextSibling != nil {
+			return n.NextSibling
+		}
+		n = n.Parent
+	}
+	return nil
+}
+
+// hasAncestor reports whether n has an ancestor element node whose
+// Data field is tag.
+func hasAncestor(n *html.Node, tag string) bool {
+	for p := n.Parent; p != nil; p = p.Parent {
+		if p.Type == html.ElementNode && p.Data == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,326 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"golang.org/x/net/html"
+)
+
+// FindRegex does a depth first search of root and returns the slice
+// of all element nodes whose Data field is tag and which have an
+// attribute with key attrKey whose value matches pattern. If tag is
+// empty elements with any tag are considered. As with Attr, the
+// Namespace fields of attributes are not compared. If there are no
+// such nodes it returns the empty slice.
+func FindRegex(root *html.Node, tag, attrKey string,
+	pattern *regexp.Regexp) []*html.Node {
+	var result []*html.Node
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type != html.ElementNode || (tag != "" && n.Data != tag) {
+			continue
+		}
+		if v, ok := Attr(n, attrKey); ok && pattern.MatchString(v) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// FindCI is like Find but compares nodes using CompareCI, so that the
+// values of attributes whose keys are listed in caseInsensitiveAttrs
+// are matched without regard to case.
+func FindCI(root *html.Node, fragment string,
+	caseInsensitiveAttrs []string) []*html.Node {
+	var result []*html.Node
+	n2 := Leaf(fragment)
+	cmp := func(n1, n2 *html.Node) bool {
+		return CompareCI(n1, n2, caseInsensitiveAttrs)
+	}
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if matchFunc(n, n2, cmp) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// MultiFind is like calling Find once for each of fragments, but
+// makes a single depth first search of root. It returns a slice
+// holding the results for each fragment, in the same order as
+// fragments, and each in document order.
+func MultiFind(root *html.Node, fragments ...string) [][]*html.Node {
+	leaves := make([]*html.Node, len(fragments))
+	for i, f := range fragments {
+		leaves[i] = Leaf(f)
+	}
+	result := make([][]*html.Node, len(fragments))
+	for n := root; n != nil; n, _ = Next(n, root) {
+		for i, l := range leaves {
+			if Match(n, l) {
+				result[i] = append(result[i], n)
+			}
+		}
+	}
+	return result
+}
+
+// FindFuzzy does a depth first search of root and returns all nodes n
+// for which MatchScore(n,Leaf(fragment)) is at least threshold,
+// sorted by descending score. Nodes with equal scores are left in
+// document order. A threshold of 1 gives the same nodes as Find.
+func FindFuzzy(root *html.Node, fragment string,
+	threshold float64) []*html.Node {
+	type scored struct {
+		n     *html.Node
+		score float64
+	}
+	var ss []scored
+	n2 := Leaf(fragment)
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if score := MatchScore(n, n2); score >= threshold {
+			ss = append(ss, scored{n, score})
+		}
+	}
+	sort.SliceStable(ss, func(i, j int) bool {
+		return ss[i].score > ss[j].score
+	})
+	result := make([]*html.Node, len(ss))
+	for i, s := range ss {
+		result[i] = s.n
+	}
+	return result
+}
+
+// FindAny does a depth first search of root and returns the slice of
+// all nodes which match at least one of fragments, in the sense of
+// Find. Each node appears at most once and the result is in document
+// order. The fragments are converted to leaf nodes once, before the
+// search begins.
+func FindAny(root *html.Node, fragments ...string) []*html.Node {
+	leaves := make([]*html.Node, len(fragments))
+	for i, f := range fragments {
+		leaves[i] = Leaf(f)
+	}
+	return FindAll(root, func(n *html.Node) bool {
+		for _, l := range leaves {
+			if Match(n, l) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// FindAll does a depth first search of root and returns the slice of
+// all nodes n for which pred(n) is true. If there are no such nodes
+// it returns the empty slice.
+func FindAll(root *html.Node, pred func(*html.Node) bool) []*html.Node {
+	var result []*html.Node
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if pred(n) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// FindByAttr returns all element nodes in the tree at root which
+// have an attribute with the given key and value, whatever their tag,
+// in document order. As with Attr, only the first attribute with the
+// key is considered and the Namespace fields are not compared.
+func FindByAttr(root *html.Node, key, val string) []*html.Node {
+	return FindAll(root, func(n *html.Node) bool {
+		v, ok := Attr(n, key)
+		return ok && v == val && n.Type == html.ElementNode
+	})
+}
+
+// FindByClass returns all element nodes in the tree at root for
+// which HasClass(n,cls) is true, in document order.
+func FindByClass(root *html.Node, cls string) []*html.Node {
+	return FindAll(root, func(n *html.Node) bool {
+		return n.Type == html.ElementNode && HasClass(n, cls)
+	})
+}
+
+// FindNone returns true if Find(root,fragment) would return the empty
+// slice, that is, if no node in root matches fragment. It stops
+// searching at the first match.
+func FindNone(root *html.Node, fragment string) bool {
+	n2 := Leaf(fragment)
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if Match(n, n2) {
+			return false
+		}
+	}
+	return true
+}
+
+// FindOne returns the single node which Find(root,fragment) would
+// return. It panics if Find would return no nodes or more than one.
+// It is intended for use in tests and in code where the document is
+// known to contain exactly one match.
+func FindOne(root *html.Node, fragment string) *html.Node {
+	ns := Find(root, fragment)
+	if len(ns) != 1 {
+		panic(fmt.Sprintf(
+			"htmlnode: FindOne: %d nodes match %q, want 1", len(ns), fragment))
+	}
+	return ns[0]
+}
+
+// FindAfter is like Find but returns only the matching nodes which
+// come after ref in document order. Since a depth first traversal
+// visits a node before its descendants, these include any matching
+// descendants of ref. If ref is not in the tree at root FindAfter
+// returns the empty slice.
+func FindAfter(root, ref *html.Node, fragment string) []*html.Node {
+	var result []*html.Node
+	n2, after := Leaf(fragment), false
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if after && Match(n, n2) {
+			result = append(result, n)
+		}
+		after = after || n == ref
+	}
+	return result
+}
+
+// FindBefore is like Find but returns only the matching nodes which
+// come before ref in document order. These include any matching
+// ancestors of ref. If ref is not in the tree at root FindBefore
+// returns the same nodes as Find.
+func FindBefore(root, ref *html.Node, fragment string) []*html.Node {
+	var result []*html.Node
+	n2 := Leaf(fragment)
+	for n := root; n != nil && n != ref; n, _ = Next(n, root) {
+		if Match(n, n2) {
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// FindBetween is like Find but returns only the matching nodes which
+// come after start and before end in document order, excluding start
+// and end themselves. If start is not in the tree at root FindBetween
+// returns the empty slice, and if end is not in the tree (or comes
+// before start) the search continues to the end of the tree.
+func FindBetween(root, start, end *html.Node, fragment string) []*html.Node {
+	var result []*html.Node
+	n2, after := Leaf(fragment), false
+	for n := root; n != nil && !(after && n == end); n, _ = Next(n, root) {
+		if after && Match(n, n2) {
+			result = append(result, n)
+		}
+		after = after || n == start
+	}
+	return result
+}
+
+// FindNth returns the n-th node, counting from 1, that Find(root,
+// fragment) would return, or nil if there are fewer than n. The
+// search stops as soon as the n-th match is found. A negative n
+// counts back from the last match, so -1 returns the last; this
+// requires a full search but only keeps the last -n matches. FindNth
+// returns nil if n is 0.
+func FindNth(root *html.Node, fragment string, n int) *html.Node {
+	if n == 0 {
+		return nil
+	}
+	n2 := Leaf(fragment)
+	var ring []*html.Node
+	count := 0
+	for m := root; m != nil; m, _ = Next(m, root) {
+		if !Match(m, n2) {
+			continue
+		}
+		count++
+		if count == n {
+			return m
+		}
+		if n < 0 {
+			if len(ring) < -n {
+				ring = append(ring, m)
+			} else {
+				ring[(count-1)%-n] = m
+			}
+		}
+	}
+	if n > 0 || count < -n {
+		return nil
+	}
+	return ring[(count+n)%-n]
+}
+
+// Count returns the number of nodes Find(root,fragment) would return,
+// without building the slice.
+func Count(root *html.Node, fragment string) int {
+	count := 0
+	n2 := Leaf(fragment)
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if Match(n, n2) {
+			count++
+		}
+	}
+	return count
+}
+
+// Exists returns true if any node in root matches fragment, that is,
+// if Find(root,fragment) would return a non-empty slice. It stops
+// searching at the first match.
+func Exists(root *html.Node, fragment string) bool {
+	return !FindNone(root, fragment)
+}
+
+// NotExists is the negation of Exists, and is equivalent to FindNone.
+func NotExists(root *html.Node, fragment string) bool {
+	return FindNone(root, fragment)
+}
+
+// MustFind is like Find but panics if no node matches fragment. The
+// panic message gives the fragment and the path from the document
+// root down to root, to help identify the tree searched. It is
+// intended for use in tests.
+func MustFind(root *html.Node, fragment string) []*html.Node {
+	ns := Find(root, fragment)
+	if len(ns) == 0 {
+		where := cssPath(root)
+		if where == "" {
+			where = "document root"
+		}
+		panic(fmt.Sprintf(
+			"htmlnode: MustFind: no nodes match %q under %s", fragment, where))
+	}
+	return ns
+}
+
+// FindComments returns all html.CommentNode nodes in the tree at
+// root, in document order. It is equivalent to CommentNodes, and is
+// provided alongside the other Find functions.
+func FindComments(root *html.Node) []*html.Node {
+	return CommentNodes(root)
+}
@@ -0,0 +1,51 @@
+//go:build go1.18
+// +build go1.18
+
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"golang.org/x/net/html"
+)
+
+// Reduce folds fn over the nodes of the tree at root in document
+// order. The accumulator starts as initial and is replaced by the
+// result of fn at each node; its final value is returned.
+func Reduce[T any](root *html.Node, initial T,
+	fn func(acc T, n *html.Node) T) T {
+	acc := initial
+	for n := root; n != nil; n, _ = Next(n, root) {
+		acc = fn(acc, n)
+	}
+	return acc
+}
+
+// Map calls fn for each node of the tree at root in document order
+// and returns the results in a slice.
+func Map[T any](root *html.Node, fn func(n *html.Node) T) []T {
+	var result []T
+	for n := root; n != nil; n, _ = Next(n, root) {
+		result = append(result, fn(n))
+	}
+	return result
+}
@@ -0,0 +1,100 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+	"io"
+	"sort"
+
+	"golang.org/x/net/html"
+)
+
+// Hash returns a fingerprint of the tree at root computed with the
+// 64 bit FNV-1a hash. Every node is visited in document order and its
+// position in the tree, Type, Data and Namespace fields, and its
+// attributes sorted by namespace, key and value, are fed to the hash.
+// So two trees which differ only in the order of their attributes
+// have the same hash, while any other difference in the fields above
+// almost certainly gives a different one.
+//
+// Hash returns any error it gets when writing to the hash, although
+// with the present implementation there are none.
+func Hash(root *html.Node) (uint64, error) {
+	h := fnv.New64a()
+	var delta int
+	for n := root; n != nil; n, delta = Next(n, root) {
+		attrs := append([]html.Attribute(nil), n.Attr...)
+		sort.Slice(attrs, func(i, j int) bool {
+			return attrLess(attrs[i], attrs[j])
+		})
+		err := hashInts(h, delta, int(n.Type), len(attrs))
+		if err == nil {
+			err = hashStrings(h, n.Data, n.Namespace)
+		}
+		for _, a := range attrs {
+			if err == nil {
+				err = hashStrings(h, a.Namespace, a.Key, a.Val)
+			}
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return h.Sum64(), nil
+}
+
+// attrLess orders attributes by namespace, then key, then value.
+func attrLess(a, b html.Attribute) bool {
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	if a.Key != b.Key {
+		return a.Key < b.Key
+	}
+	return a.Val < b.Val
+}
+
+// hashInts writes each of is to w as a fixed size integer.
+func hashInts(w io.Writer, is ...int) error {
+	for _, i := range is {
+		if err := binary.Write(w, binary.LittleEndian, int64(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hashStrings writes each of ss to w preceded by its length, so that
+// the boundaries between strings are part of what is hashed.
+func hashStrings(w io.Writer, ss ...string) error {
+	for _, s := range ss {
+		if err := hashInts(w, len(s)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
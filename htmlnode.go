@@ -126,6 +126,13 @@
 //
 // even though there is no <table> in subtree. The matcher will look
 // look in subtree's parents.
+//
+// Alternatively, FindInContext parses the fragment in the context of
+// an element node of your choosing, so with a <table> context node
+//
+//   FindInContext(subtree, `<tr><td>`, table)
+//
+// works as expected.
 package htmlnode // import "xi2.org/x/htmlnode"
 
 import (
@@ -133,6 +140,7 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 
 	"golang.org/x/net/html"
 )
@@ -148,14 +156,36 @@ func Compare(n1, n2 *html.Node) bool {
 		n1.Namespace != n2.Namespace {
 		return false
 	}
-	am := map[html.Attribute]struct{}{}
-	for _, a := range n1.Attr {
-		am[a] = struct{}{}
+	return HasAttributeSubset(n1, n2.Attr)
+}
+
+// CompareCI is like Compare but the values of attributes whose keys
+// are listed in caseInsensitiveAttrs are compared without regard to
+// case, using strings.EqualFold.
+func CompareCI(n1, n2 *html.Node, caseInsensitiveAttrs []string) bool {
+	if n1 == nil || n2 == nil {
+		return false
 	}
-	for _, a := range n2.Attr {
-		if _, ok := am[a]; !ok {
-			return false
+	if n1.Type != n2.Type || n1.Data != n2.Data ||
+		n1.Namespace != n2.Namespace {
+		return false
+	}
+	ci := map[string]bool{}
+	for _, k := range caseInsensitiveAttrs {
+		ci[k] = true
+	}
+outer:
+	for _, a2 := range n2.Attr {
+		for _, a1 := range n1.Attr {
+			if a1.Namespace != a2.Namespace || a1.Key != a2.Key {
+				continue
+			}
+			if a1.Val == a2.Val ||
+				(ci[a1.Key] && strings.EqualFold(a1.Val, a2.Val)) {
+				continue outer
+			}
 		}
+		return false
 	}
 	return true
 }
@@ -165,8 +195,56 @@ func Compare(n1, n2 *html.Node) bool {
 // root down to n2. Call these slices ns1 and ns2. If the tail of ns1
 // matches ns2 with respect to Compare then Match returns true.
 func Match(n1 *html.Node, n2 *html.Node) bool {
+	return matchFunc(n1, n2, Compare)
+}
+
+// MatchLeaf is identical to Match. Its name and parameter names make
+// clear that leaf should be a node returned by Leaf (or one of its
+// variants), and candidate a node in the tree being searched.
+func MatchLeaf(candidate, leaf *html.Node) bool {
+	return Match(candidate, leaf)
+}
+
+// MatchPath returns true if Match(n,Leaf(f)) is true for any f in
+// fragments, allowing several alternative fragments to be tested at
+// once, as with a group of CSS selectors.
+func MatchPath(n *html.Node, fragments ...string) bool {
+	for _, f := range fragments {
+		if Match(n, Leaf(f)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchNth returns true if Match(n,Leaf(fragment)) is true and the
+// position of n among those of its siblings (including n) which also
+// match, counting from 1, is a*k+b for some integer k >= 0. This is
+// the an+b formula of the CSS :nth-child and :nth-of-type
+// pseudo-classes; for example a=2, b=1 selects the odd positions and
+// a=0, b=3 just the third.
+func MatchNth(n *html.Node, fragment string, a, b int) bool {
+	n2 := Leaf(fragment)
+	if !Match(n, n2) {
+		return false
+	}
+	pos := 1
+	for s := n.PrevSibling; s != nil; s = s.PrevSibling {
+		if Match(s, n2) {
+			pos++
+		}
+	}
+	if a == 0 {
+		return pos == b
+	}
+	return (pos-b)%a == 0 && (pos-b)/a >= 0
+}
+
+// matchFunc is like Match but compares nodes using cmp instead of
+// Compare.
+func matchFunc(n1, n2 *html.Node, cmp func(n1, n2 *html.Node) bool) bool {
 	for n1 != nil && n2 != nil {
-		if !Compare(n1, n2) {
+		if !cmp(n1, n2) {
 			return false
 		}
 		n1 = n1.Parent
@@ -178,6 +256,92 @@ func Match(n1 *html.Node, n2 *html.Node) bool {
 	return true
 }
 
+// MatchTrim is like Match but text nodes are compared with leading
+// and trailing whitespace removed from their Data fields, so that
+// Leaf(`<p>text`) matches the text node of <p>  text  </p>. It is
+// intended for use with leaves from LeafOpts with TrimWhitespace set.
+func MatchTrim(n1, n2 *html.Node) bool {
+	return matchFunc(n1, n2, compareTrim)
+}
+
+// compareTrim is like Compare but ignores leading and trailing
+// whitespace in the Data fields of text nodes.
+func compareTrim(n1, n2 *html.Node) bool {
+	if n1 == nil || n2 == nil {
+		return false
+	}
+	if n1.Type == html.TextNode && n2.Type == html.TextNode {
+		return n1.Namespace == n2.Namespace &&
+			strings.TrimSpace(n1.Data) == strings.TrimSpace(n2.Data)
+	}
+	return Compare(n1, n2)
+}
+
+// MatchScore is a graded version of Match, returning a value between
+// 0 and 1 measuring how closely n1 matches n2. Like Match it traces
+// n1 and n2 up towards their roots in step, and it returns the mean
+// of the scores of the pairs of nodes visited, stopping when n2's
+// root has been scored. Where n1 runs out of ancestors first, the
+// remaining nodes of n2 score 0.
+//
+// A pair of nodes of different Type scores 0. A pair of non-element
+// nodes scores 1 if their Data fields are equal and 0 otherwise. A
+// pair of element nodes scores 0.5 if the element names (Data and
+// Namespace) are equal, plus 0.5 times the fraction of the attributes
+// of the node from n2's tree which also appear on the node from n1's
+// tree (a node with no attributes counting as a full match).
+//
+// So for non-nil n2, MatchScore(n1,n2) is 1 exactly when Match(n1,n2)
+// is true.
+func MatchScore(n1, n2 *html.Node) float64 {
+	if n2 == nil {
+		return 0
+	}
+	var total float64
+	var count int
+	for ; n2 != nil; n2 = n2.Parent {
+		count++
+		if n1 == nil {
+			continue
+		}
+		total += nodeScore(n1, n2)
+		n1 = n1.Parent
+	}
+	return total / float64(count)
+}
+
+// nodeScore returns the score of the pair of nodes n1 and n2 as
+// described in MatchScore.
+func nodeScore(n1, n2 *html.Node) float64 {
+	if n1.Type != n2.Type {
+		return 0
+	}
+	if n1.Type != html.ElementNode {
+		if n1.Data == n2.Data && n1.Namespace == n2.Namespace {
+			return 1
+		}
+		return 0
+	}
+	var score float64
+	if n1.Data == n2.Data && n1.Namespace == n2.Namespace {
+		score = 0.5
+	}
+	if len(n2.Attr) == 0 {
+		return score + 0.5
+	}
+	am := map[html.Attribute]struct{}{}
+	for _, a := range n1.Attr {
+		am[a] = struct{}{}
+	}
+	var found int
+	for _, a := range n2.Attr {
+		if _, ok := am[a]; ok {
+			found++
+		}
+	}
+	return score + 0.5*float64(found)/float64(len(n2.Attr))
+}
+
 // Leaf converts an HTML fragment into a parse tree (without
 // html/head/body ElementNodes or DoctypeNode), and then from the root
 // of this tree repeatedly follows FirstChild until it finds a leaf
@@ -188,21 +352,124 @@ func Match(n1 *html.Node, n2 *html.Node) bool {
 // of type html.ErrorNode. The return value of Leaf is intended to be
 // passed to Match as its second argument.
 func Leaf(fragment string) *html.Node {
-	ns, err := html.ParseFragment(
-		strings.NewReader(fragment), &html.Node{Type: html.ElementNode})
+	return LeafWithContext(fragment, nil)
+}
+
+// MustLeaf is like Leaf but panics if fragment cannot be converted
+// into a leaf node, that is, if Leaf would return a node of type
+// html.ErrorNode or nil. It simplifies the initialization of global
+// variables holding leaf nodes.
+func MustLeaf(fragment string) *html.Node {
+	n := Leaf(fragment)
+	if n == nil || n.Type == html.ErrorNode {
+		panic(fmt.Sprintf(
+			"htmlnode: MustLeaf(%q): fragment does not parse", fragment))
+	}
+	return n
+}
+
+// LeafWithContext is like Leaf but parses fragment in the context of
+// the element node context, which is passed to html.ParseFragment.
+// This allows fragments which are only valid inside particular
+// elements to be used, for example `<tr><td>` with the context
+// &html.Node{Type: html.ElementNode, Data: "table", DataAtom:
+// atom.Table}. Note that the parser inspects the DataAtom field of
+// context, so it must be set. If context is nil the generic context
+// used by Leaf is substituted.
+func LeafWithContext(fragment string, context *html.Node) *html.Node {
+	return LeafOpts(fragment, ParseOptions{ContextNode: context})
+}
+
+// LeafTag returns the tag name of the leaf node Leaf(fragment), or
+// the empty string if that node is not an element node.
+func LeafTag(fragment string) string {
+	n := Leaf(fragment)
+	if n == nil || n.Type != html.ElementNode {
+		return ""
+	}
+	return n.Data
+}
+
+// LeafAttrs returns the attributes of the leaf node Leaf(fragment).
+func LeafAttrs(fragment string) []html.Attribute {
+	n := Leaf(fragment)
+	if n == nil {
+		return nil
+	}
+	return n.Attr
+}
+
+// ParseOptions controls how LeafOpts converts a fragment into a leaf
+// node.
+type ParseOptions struct {
+	// TrimWhitespace causes whitespace-only text nodes to be passed
+	// over when following FirstChild down the parse tree, so that
+	// fragments spread over several lines lead to the intended leaf.
+	// If the leaf found is a text node, leading and trailing
+	// whitespace is also removed from its Data field. Since Match
+	// requires Data fields to be equal, such a leaf should be
+	// matched against a document with MatchTrim, which ignores
+	// leading and trailing whitespace in the text nodes of both
+	// trees.
+	TrimWhitespace bool
+	// ContextNode is the context passed to html.ParseFragment, as
+	// for LeafWithContext. If it is nil the generic context used by
+	// Leaf is substituted.
+	ContextNode *html.Node
+}
+
+// LeafOpts is like Leaf but converts fragment into a leaf node
+// according to opts.
+func LeafOpts(fragment string, opts ParseOptions) *html.Node {
+	context := opts.ContextNode
+	if context == nil {
+		context = &html.Node{Type: html.ElementNode}
+	}
+	ns, err := html.ParseFragment(strings.NewReader(fragment), context)
 	if err != nil || len(ns) == 0 {
 		return &html.Node{Type: html.ErrorNode}
 	}
 	n := ns[0]
+	if opts.TrimWhitespace {
+		for _, m := range ns {
+			if !isSpace(m) {
+				n = m
+				break
+			}
+		}
+	}
 	if n == nil {
 		return nil
 	}
 	for n.FirstChild != nil {
 		n = n.FirstChild
+		if opts.TrimWhitespace {
+			n = firstNonSpace(n)
+		}
+	}
+	if opts.TrimWhitespace && n.Type == html.TextNode {
+		n.Data = strings.TrimSpace(n.Data)
+	}
+	return n
+}
+
+// firstNonSpace returns the first of n and its following siblings
+// which is not a whitespace-only text node, or n if there is none.
+func firstNonSpace(n *html.Node) *html.Node {
+	for m := n; m != nil; m = m.NextSibling {
+		if !isSpace(m) {
+			return m
+		}
 	}
 	return n
 }
 
+// isSpace reports whether n is a whitespace-only text node.
+func isSpace(n *html.Node) bool {
+	return n != nil && n.Type == html.TextNode &&
+		strings.TrimSpace(n.Data) == ""
+}
+
 // Attr returns the Val field of the first attribute in n.Attr whose
 // Key field is equal to key. The second return value indicates if the
 // node has such an attribute. If no such attribute exists Attr
@@ -264,6 +531,21 @@ func PrevSibElt(n *html.Node) *html.Node {
 	return nil
 }
 
+// ParentElt returns the nearest ancestor of node n with type
+// html.ElementNode (or nil if no such ancestor).
+func ParentElt(n *html.Node) *html.Node {
+	if n == nil {
+		return nil
+	}
+	for n.Parent != nil {
+		if n.Parent.Type == html.ElementNode {
+			return n.Parent
+		}
+		n = n.Parent
+	}
+	return nil
+}
+
 // Next returns the next node in a depth first traversal of the tree
 // at root (where the current node is node n), together with a delta
 // indicating by how much it has descended or ascended the tree
@@ -294,10 +576,27 @@ func Next(n *html.Node, root *html.Node) (*html.Node, int) {
 }
 
 // Prev behaves like Next, but returns the previous node instead.
+//
+// If n is nil, Prev returns the deepest rightmost leaf of root (found
+// by repeatedly following LastChild), which is the last node visited
+// when walking root with Next, together with its depth below root as
+// the delta. If both n and root are nil Prev returns nil.
+//
+// Note that, like Next, Prev visits a node before its children, taking
+// the children from last to first. So walking on from the deepest
+// rightmost leaf with Prev does not give the reverse of document
+// order, and misses most of root; to visit every node of root with
+// Prev, start at root itself.
 func Prev(n *html.Node, root *html.Node) (*html.Node, int) {
 	delta := 0
 	if n == nil {
-		return nil, delta
+		if root == nil {
+			return nil, delta
+		}
+		for n = root; n.LastChild != nil; n = n.LastChild {
+			delta += 1
+		}
+		return n, delta
 	}
 	if n.LastChild != nil {
 		delta += 1
@@ -326,13 +625,27 @@ func Prev(n *html.Node, root *html.Node) (*html.Node, int) {
 // generic element node as its parent, since it is passed to Leaf. See
 // "A note on fragments" in the introduction for more details.
 func Find(root *html.Node, fragment string) []*html.Node {
+	return findLeaf(root, Leaf(fragment))
+}
+
+// FindInContext is like Find but converts fragment into a leaf node
+// using LeafWithContext with the given context node. The context node
+// need not be part of root. For example, with a <table> context node
+// the fragment `<tr><td>` may be used, which would not parse with
+// Find.
+func FindInContext(root *html.Node, fragment string,
+	context *html.Node) []*html.Node {
+	return findLeaf(root, LeafWithContext(fragment, context))
+}
+
+// findLeaf returns the slice of all nodes n in root which satisfy
+// Match(n,leaf), in depth first order.
+func findLeaf(root, leaf *html.Node) []*html.Node {
 	var result []*html.Node
-	n, n2 := root, Leaf(fragment)
-	for n != nil {
-		if Match(n, n2) {
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if Match(n, leaf) {
 			result = append(result, n)
 		}
-		n, _ = Next(n, root)
 	}
 	return result
 }
@@ -349,20 +662,129 @@ func Flatten(root *html.Node) string {
 	return s
 }
 
+// FlattenTo is like Flatten but writes the Data field of each
+// html.TextNode to w as it is found, rather than building a string.
+// It returns the first error encountered writing to w.
+func FlattenTo(root *html.Node, w io.Writer) error {
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type == html.TextNode {
+			if _, err := io.WriteString(w, n.Data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// FlattenFilter is like Flatten but calls include on each
+// html.ElementNode found, skipping the element and its entire subtree
+// if include returns false. For example, passing a function returning
+// false for <script> and <style> elements gives only the text a
+// browser would display.
+func FlattenFilter(root *html.Node, include func(n *html.Node) bool) string {
+	var b strings.Builder
+	n := root
+	for n != nil {
+		if n.Type == html.ElementNode && !include(n) {
+			n = skip(n, root)
+			continue
+		}
+		if n.Type == html.TextNode {
+			b.WriteString(n.Data)
+		}
+		n, _ = Next(n, root)
+	}
+	return b.String()
+}
+
 // String returns a human readable representation of the single node
 // n, with optional terminal colouring using ANSI escape codes. The
 // representation begins with a capital letter indicating the
 // html.NodeType. These are one of: X - ErrorNode, T - TextNode, R -
 // DocumentNode, E - ElementNode, C - CommentNode, D - DoctypeNode.
+//
+// String is equivalent to StringTheme with DefaultTheme if colour is
+// true and NoColorTheme otherwise.
 func String(n *html.Node, colour bool) string {
+	return StringTheme(n, theme(colour))
+}
+
+// StringShort returns String(n,false) truncated to its first maxLen
+// characters, with "..." appended if anything was cut off. It is
+// intended for log lines, where a long text node or a long list of
+// attributes would otherwise swamp the output.
+func StringShort(n *html.Node, maxLen int) string {
+	s := String(n, false)
+	if maxLen < 0 {
+		maxLen = 0
+	}
+	i := 0
+	for j := range s {
+		if i == maxLen {
+			return s[:j] + "..."
+		}
+		i++
+	}
+	return s
+}
+
+// ColorTheme holds the escape sequences StringTheme uses to colour
+// each part of a node's representation. Type colours the capital
+// letter indicating the html.NodeType. Error, Text, Document, Comment
+// and Doctype colour the Data field of the corresponding node types.
+// Element colours the name of an element, and Attr and AttrVal the
+// keys and values of its attributes. NS colours namespaces; if it is
+// empty a namespace takes the colour of the name it qualifies. A part
+// whose sequence is empty is left uncoloured. Each coloured line is
+// followed by the ANSI reset sequence.
+//
+// A ColorTheme is also a Printer, printing nodes with StringTheme.
+type ColorTheme struct {
+	Type                                             string
+	Error, Text, Document, Element, Comment, Doctype string
+	Attr, AttrVal, NS                                string
+}
+
+var (
+	// DefaultTheme is the theme used by String when colour is true.
+	DefaultTheme = ColorTheme{
+		Type:     "\033[35m",
+		Error:    "\033[34m",
+		Document: "\033[34m",
+		Element:  "\033[31m",
+		Comment:  "\033[32m",
+		Doctype:  "\033[34m",
+		Attr:     "\033[33m",
+		AttrVal:  "\033[36m",
+	}
+	// NoColorTheme is the theme used by String when colour is
+	// false. It adds no escape sequences.
+	NoColorTheme = ColorTheme{}
+)
+
+// theme returns DefaultTheme if colour is true and NoColorTheme
+// otherwise.
+func theme(colour bool) ColorTheme {
+	if colour {
+		return DefaultTheme
+	}
+	return NoColorTheme
+}
+
+// Print calls StringTheme with theme t.
+func (t ColorTheme) Print(n *html.Node) string {
+	return StringTheme(n, t)
+}
+
+// StringTheme is like String but colours the representation of n
+// using the escape sequences in theme.
+func StringTheme(n *html.Node, theme ColorTheme) string {
 	if n == nil {
 		return ""
 	}
-	red, gre, yel := "\033[31m", "\033[32m", "\033[33m"
-	blu, mag, cya := "\033[34m", "\033[35m", "\033[36m"
 	rst := "\033[0m"
 	c := func(str, col string) string {
-		if colour {
+		if col != "" {
 			var cs string
 			for _, s := range strings.Split(str, "\n") {
 				cs = cs + col + s + rst + "\n"
@@ -371,36 +793,89 @@ func String(n *html.Node, colour bool) string {
 		}
 		return str
 	}
+	ns := func(col string) string {
+		if theme.NS != "" {
+			return theme.NS
+		}
+		return col
+	}
 	switch n.Type {
 	case html.ErrorNode:
-		return c("X ", mag) + c(n.Data, blu)
+		return c("X ", theme.Type) + c(n.Data, theme.Error)
 	case html.TextNode:
-		return c("T ", mag) + n.Data
+		return c("T ", theme.Type) + c(n.Data, theme.Text)
 	case html.DocumentNode:
-		return c("R ", mag) + c(n.Data, blu)
+		return c("R ", theme.Type) + c(n.Data, theme.Document)
 	case html.ElementNode:
 		var attrs string
 		for _, a := range n.Attr {
-			name := c(a.Key, yel)
+			name := c(a.Key, theme.Attr)
 			sVal := fmt.Sprintf("%#v", a.Val)
 			if a.Namespace != "" {
-				name = c(a.Namespace, yel) + ":" + name
+				name = c(a.Namespace, ns(theme.Attr)) + ":" + name
 			}
-			attrs += " " + name + "=" + c(sVal, cya)
+			attrs += " " + name + "=" + c(sVal, theme.AttrVal)
 		}
-		name := c(n.Data, red)
+		name := c(n.Data, theme.Element)
 		if n.Namespace != "" {
-			name = c(n.Namespace, red) + ":" + name
+			name = c(n.Namespace, ns(theme.Element)) + ":" + name
 		}
-		return c("E ", mag) + name + attrs
+		return c("E ", theme.Type) + name + attrs
 	case html.CommentNode:
-		return c("C ", mag) + c(n.Data, gre)
+		return c("C ", theme.Type) + c(n.Data, theme.Comment)
 	case html.DoctypeNode:
-		return c("D ", mag) + c(n.Data, blu)
+		return c("D ", theme.Type) + c(n.Data, theme.Doctype)
 	}
 	return ""
 }
 
+// A Printer returns a human readable representation of a single
+// node. It is used by StringWith and PrintTreeWith in place of
+// String, allowing the format of each node to be customized.
+type Printer interface {
+	Print(n *html.Node) string
+}
+
+var (
+	printerMu sync.RWMutex
+	printer   Printer = NoColorTheme
+)
+
+// RegisterPrinter sets the Printer used by StringWith and
+// PrintTreeWith when they are passed a nil Printer. Initially this
+// is NoColorTheme, which prints nodes as String does with colour set
+// to false. Passing nil restores the initial Printer. RegisterPrinter
+// is safe to call concurrently with the functions which use the
+// Printer.
+func RegisterPrinter(p Printer) {
+	if p == nil {
+		p = NoColorTheme
+	}
+	printerMu.Lock()
+	printer = p
+	printerMu.Unlock()
+}
+
+// registeredPrinter returns the Printer set by RegisterPrinter.
+func registeredPrinter() Printer {
+	printerMu.RLock()
+	defer printerMu.RUnlock()
+	return printer
+}
+
+// StringWith returns the representation of the single node n given
+// by p. If p is nil the Printer set by RegisterPrinter is used. As
+// with String, StringWith returns the empty string if n is nil.
+func StringWith(n *html.Node, p Printer) string {
+	if n == nil {
+		return ""
+	}
+	if p == nil {
+		p = registeredPrinter()
+	}
+	return p.Print(n)
+}
+
 // PrintTree prints the tree at root to the supplied io.Writer using
 // String to print the nodes. It uses indention to convey the document
 // structure. Like String, it can optionally colourize the output. It
@@ -408,13 +883,44 @@ func String(n *html.Node, colour bool) string {
 //
 // PrintTree returns any error it gets when calling fmt.Fprintf.
 func PrintTree(w io.Writer, root *html.Node, colour bool) error {
+	return PrintTreeWith(w, root, theme(colour))
+}
+
+// PrintTreeWith is like PrintTree but uses p to print the nodes. If p
+// is nil the Printer set by RegisterPrinter is used.
+func PrintTreeWith(w io.Writer, root *html.Node, p Printer) error {
+	return printTree(root, p, func(line string) error {
+		_, err := fmt.Fprintf(w, "%s\n", line)
+		return err
+	})
+}
+
+// PrintTreeLines is like PrintTree but returns the lines of output as
+// a slice of strings, without trailing newlines, rather than writing
+// them to an io.Writer. A line may contain newlines of its own if the
+// Data field of a node does.
+func PrintTreeLines(root *html.Node, colour bool) []string {
+	var lines []string
+	printTree(root, theme(colour), func(line string) error {
+		lines = append(lines, line)
+		return nil
+	})
+	return lines
+}
+
+// printTree calls line with the indented representation of each node
+// under root, as printed by p, stopping at the first error returned
+// by line. If p is nil the Printer set by RegisterPrinter is used.
+func printTree(root *html.Node, p Printer, line func(string) error) error {
+	if p == nil {
+		p = registeredPrinter()
+	}
 	indent, n := "", root
 	var delta int
 	for n != nil {
 		if n.Type != html.TextNode || strings.Trim(n.Data, "\r\n\t ") != "" {
 			// print (skipping whitespace only TextNodes)
-			_, err := fmt.Fprintf(w, "%s%s\n", indent, String(n, colour))
-			if err != nil {
+			if err := line(indent + p.Print(n)); err != nil {
 				return err
 			}
 		}
@@ -436,3 +942,27 @@ func PrintTree(w io.Writer, root *html.Node, colour bool) error {
 func Print(root *html.Node) error {
 	return PrintTree(os.Stdout, root, true)
 }
+
+// RenderNode renders the tree at n as HTML using html.Render and
+// returns the result as a string, together with any error returned
+// by html.Render. If n is nil RenderNode returns ("",nil).
+func RenderNode(n *html.Node) (string, error) {
+	if n == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	if err := html.Render(&b, n); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// MustRenderNode is like RenderNode but panics if html.Render returns
+// an error. It is intended for use in tests.
+func MustRenderNode(n *html.Node) string {
+	s, err := RenderNode(n)
+	if err != nil {
+		panic("htmlnode: MustRenderNode: " + err.Error())
+	}
+	return s
+}
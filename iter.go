@@ -0,0 +1,89 @@
+//go:build go1.23
+// +build go1.23
+
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"iter"
+
+	"golang.org/x/net/html"
+)
+
+// IterChildren returns an iterator over the direct children of n, in
+// order.
+func IterChildren(n *html.Node) iter.Seq[*html.Node] {
+	return func(yield func(*html.Node) bool) {
+		if n == nil {
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if !yield(c) {
+				return
+			}
+		}
+	}
+}
+
+// IterDescendants returns an iterator over the descendants of root in
+// depth first order, yielding each node together with its depth below
+// root (1 for the children of root). Root itself is not yielded.
+func IterDescendants(root *html.Node) iter.Seq2[*html.Node, int] {
+	return func(yield func(*html.Node, int) bool) {
+		depth := 0
+		for n, delta := Next(root, root); n != nil; n, delta = Next(n, root) {
+			depth += delta
+			if !yield(n, depth) {
+				return
+			}
+		}
+	}
+}
+
+// IterElements returns an iterator over the descendants of root of
+// type html.ElementNode, in depth first order. Root itself is not
+// yielded. Nodes are found lazily as the iterator is consumed.
+func IterElements(root *html.Node) iter.Seq[*html.Node] {
+	return func(yield func(*html.Node) bool) {
+		for n := range IterDescendants(root) {
+			if n.Type == html.ElementNode && !yield(n) {
+				return
+			}
+		}
+	}
+}
+
+// IterFind returns an iterator over the nodes Find(root,fragment)
+// would return, in the same order. No slice is built: the search
+// advances only as the iterator is consumed and stops when the loop
+// over it ends.
+func IterFind(root *html.Node, fragment string) iter.Seq[*html.Node] {
+	return func(yield func(*html.Node) bool) {
+		n2 := Leaf(fragment)
+		for n := root; n != nil; n, _ = Next(n, root) {
+			if Match(n, n2) && !yield(n) {
+				return
+			}
+		}
+	}
+}
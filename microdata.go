@@ -0,0 +1,161 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// ExtractMicrodata returns the top-level microdata items in the tree
+// at root, in document order. A top-level item is an element with an
+// itemscope attribute but no itemprop attribute.
+//
+// Each item is returned as a map from property name to a slice of
+// values, one for each element carrying that name in its itemprop
+// attribute. A value is a nested item (another map of the same form)
+// if the property element itself has an itemscope attribute, and
+// otherwise a string taken from the element as described in the HTML
+// microdata specification: content for <meta>, src for media
+// elements, href for <a>, <area> and <link>, data for <object>,
+// value for <data> and <meter>, datetime for <time>, and the Flatten
+// text of the element for anything else. The itemtype and itemid
+// attributes of an item, where present, are stored as plain strings
+// under the keys "@type" and "@id".
+//
+// Elements named in an item's itemref attribute are looked up by id
+// in the whole document containing root and searched for properties
+// as if they were children of the item.
+func ExtractMicrodata(root *html.Node) []map[string]interface{} {
+	if root == nil {
+		return nil
+	}
+	top := DocumentRoot(root)
+	ids := map[string]*html.Node{}
+	for n := top; n != nil; n, _ = Next(n, top) {
+		if id, ok := Attr(n, "id"); ok && n.Type == html.ElementNode {
+			if _, dup := ids[id]; !dup {
+				ids[id] = n
+			}
+		}
+	}
+	var result []map[string]interface{}
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		_, scope := Attr(n, "itemscope")
+		_, prop := Attr(n, "itemprop")
+		if scope && !prop {
+			result = append(result, microdataItem(n, ids, map[*html.Node]bool{}))
+		}
+	}
+	return result
+}
+
+// microdataItem builds the property map for the item whose root
+// element is item. The visiting map holds the items currently being
+// built and guards against cycles introduced by itemref.
+func microdataItem(item *html.Node, ids map[string]*html.Node,
+	visiting map[*html.Node]bool) map[string]interface{} {
+	m := map[string]interface{}{}
+	if t, ok := Attr(item, "itemtype"); ok {
+		m["@type"] = t
+	}
+	if id, ok := Attr(item, "itemid"); ok {
+		m["@id"] = id
+	}
+	visiting[item] = true
+	defer delete(visiting, item)
+	var pending []*html.Node
+	for c := item.FirstChild; c != nil; c = c.NextSibling {
+		pending = append(pending, c)
+	}
+	refs, _ := Attr(item, "itemref")
+	for _, id := range strings.Fields(refs) {
+		if n, ok := ids[id]; ok {
+			pending = append(pending, n)
+		}
+	}
+	seen := map[*html.Node]bool{}
+	for len(pending) > 0 {
+		n := pending[0]
+		pending = pending[1:]
+		if n.Type != html.ElementNode || seen[n] {
+			continue
+		}
+		seen[n] = true
+		names, prop := Attr(n, "itemprop")
+		_, scope := Attr(n, "itemscope")
+		if prop {
+			var v interface{}
+			switch {
+			case scope && visiting[n]:
+				continue
+			case scope:
+				v = microdataItem(n, ids, visiting)
+			default:
+				v = microdataValue(n)
+			}
+			for _, name := range strings.Fields(names) {
+				vs, _ := m[name].([]interface{})
+				m[name] = append(vs, v)
+			}
+		}
+		if scope {
+			continue
+		}
+		var children []*html.Node
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			children = append(children, c)
+		}
+		pending = append(children, pending...)
+	}
+	return m
+}
+
+// microdataValue returns the string value of the property element n.
+func microdataValue(n *html.Node) string {
+	var key string
+	switch n.Data {
+	case "meta":
+		key = "content"
+	case "audio", "embed", "iframe", "img", "source", "track", "video":
+		key = "src"
+	case "a", "area", "link":
+		key = "href"
+	case "object":
+		key = "data"
+	case "data", "meter":
+		key = "value"
+	case "time":
+		if v, ok := Attr(n, "datetime"); ok {
+			return v
+		}
+	}
+	if key != "" {
+		v, _ := Attr(n, key)
+		return v
+	}
+	return Flatten(n)
+}
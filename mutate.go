@@ -0,0 +1,254 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"errors"
+	"sort"
+
+	"golang.org/x/net/html"
+)
+
+// SortAttributes sorts the attributes of node n in place, ordering
+// them by Namespace, then Key, then Val.
+func SortAttributes(n *html.Node) {
+	if n == nil {
+		return
+	}
+	sort.SliceStable(n.Attr, func(i, j int) bool {
+		return attrLess(n.Attr[i], n.Attr[j])
+	})
+}
+
+// SortAttributesTree calls SortAttributes on every node in the tree
+// at root.
+func SortAttributesTree(root *html.Node) {
+	for n := root; n != nil; n, _ = Next(n, root) {
+		SortAttributes(n)
+	}
+}
+
+// Snapshot returns a deep copy of the tree at root, which can later be
+// passed to RestoreSnapshot to undo changes made to the tree. The
+// copy has no parent or siblings. Snapshot returns nil if root is nil.
+func Snapshot(root *html.Node) *html.Node {
+	return clone(root)
+}
+
+// RestoreSnapshot replaces the content of target with that of
+// snapshot: the Type, DataAtom, Data, Namespace and Attr fields of
+// target are set from snapshot and its children are replaced by
+// copies of snapshot's children. The position of target in its tree
+// is unchanged. Since copies are used, snapshot is left intact and
+// may be restored again.
+func RestoreSnapshot(target, snapshot *html.Node) {
+	if target == nil || snapshot == nil {
+		return
+	}
+	for target.FirstChild != nil {
+		target.RemoveChild(target.FirstChild)
+	}
+	target.Type = snapshot.Type
+	target.DataAtom = snapshot.DataAtom
+	target.Data = snapshot.Data
+	target.Namespace = snapshot.Namespace
+	target.Attr = append([]html.Attribute(nil), snapshot.Attr...)
+	for c := snapshot.FirstChild; c != nil; c = c.NextSibling {
+		target.AppendChild(clone(c))
+	}
+}
+
+// clone returns a deep copy of the tree at n, detached from n's
+// parent and siblings.
+func clone(n *html.Node) *html.Node {
+	if n == nil {
+		return nil
+	}
+	m := &html.Node{
+		Type:      n.Type,
+		DataAtom:  n.DataAtom,
+		Data:      n.Data,
+		Namespace: n.Namespace,
+		Attr:      append([]html.Attribute(nil), n.Attr...),
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		m.AppendChild(clone(c))
+	}
+	return m
+}
+
+// ReplaceTextContent removes the direct children of n of type
+// html.TextNode and puts a single text node with Data newText in
+// place of the first of them, or after the last child if there were
+// none. Other children of n are left where they are.
+func ReplaceTextContent(n *html.Node, newText string) {
+	if n == nil {
+		return
+	}
+	t := &html.Node{Type: html.TextNode, Data: newText}
+	var first *html.Node
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		if c.Type == html.TextNode {
+			if first == nil {
+				first = c
+				n.InsertBefore(t, c)
+			}
+			n.RemoveChild(c)
+		}
+		c = next
+	}
+	if first == nil {
+		n.AppendChild(t)
+	}
+}
+
+// SetAttr sets the value of the first attribute of n with the given
+// key and an empty Namespace to val, or appends such an attribute to
+// n.Attr if there is none.
+func SetAttr(n *html.Node, key, val string) {
+	if n == nil {
+		return
+	}
+	for i, a := range n.Attr {
+		if a.Key == key && a.Namespace == "" {
+			n.Attr[i].Val = val
+			return
+		}
+	}
+	n.Attr = append(n.Attr, html.Attribute{Key: key, Val: val})
+}
+
+// SetAttrMulti calls SetAttr for each key and value in attrs. Any new
+// attributes are appended in order of key, so that the result does
+// not depend on the iteration order of the map.
+func SetAttrMulti(n *html.Node, attrs map[string]string) {
+	keys := make([]string, 0, len(attrs))
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		SetAttr(n, k, attrs[k])
+	}
+}
+
+// ClearAttrs removes all attributes from n by setting n.Attr to nil.
+func ClearAttrs(n *html.Node) {
+	if n != nil {
+		n.Attr = nil
+	}
+}
+
+// SwapNodes exchanges the positions of a and b, so that each takes
+// the place of the other among its parent's children, along with its
+// subtree. Either node may have no parent, in which case the other
+// node is detached from its tree. SwapNodes returns an error, leaving
+// both trees unchanged, if either node is nil or is an ancestor of
+// the other.
+func SwapNodes(a, b *html.Node) error {
+	if a == nil || b == nil {
+		return errors.New("htmlnode: SwapNodes: nil node")
+	}
+	if a == b {
+		return nil
+	}
+	for p := a.Parent; p != nil; p = p.Parent {
+		if p == b {
+			return errors.New("htmlnode: SwapNodes: b is an ancestor of a")
+		}
+	}
+	for p := b.Parent; p != nil; p = p.Parent {
+		if p == a {
+			return errors.New("htmlnode: SwapNodes: a is an ancestor of b")
+		}
+	}
+	// Mark the positions of a and b with placeholders, so that the
+	// case of adjacent siblings needs no special handling.
+	pa, pb := a.Parent, b.Parent
+	ta, tb := &html.Node{}, &html.Node{}
+	if pa != nil {
+		pa.InsertBefore(ta, a)
+		pa.RemoveChild(a)
+	}
+	if pb != nil {
+		pb.InsertBefore(tb, b)
+		pb.RemoveChild(b)
+	}
+	if pa != nil {
+		pa.InsertBefore(b, ta)
+		pa.RemoveChild(ta)
+	}
+	if pb != nil {
+		pb.InsertBefore(a, tb)
+		pb.RemoveChild(tb)
+	}
+	return nil
+}
+
+// StripComments removes all html.CommentNode descendants of root
+// from the tree. The nodes are collected before any is removed, so
+// the traversal is not disturbed by the removals.
+func StripComments(root *html.Node) {
+	for _, n := range CommentNodes(root) {
+		if n != root {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// StripElements removes each node below root matching fragment, as
+// found by Find(root,fragment), from the tree. Each node is removed
+// along with its entire subtree, so for example
+//
+//	StripElements(root, `<script>`)
+//
+// removes all scripts and their contents. If root itself matches it
+// is not removed.
+func StripElements(root *html.Node, fragment string) {
+	for _, n := range Find(root, fragment) {
+		if n != root && n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// UnwrapElements replaces each node below root matching fragment, as
+// found by Find(root,fragment), with its children, in order. This
+// removes wrapper elements such as <div> or <span> while keeping
+// their content. If root itself matches it is not unwrapped.
+func UnwrapElements(root *html.Node, fragment string) {
+	for _, n := range Find(root, fragment) {
+		if n != root && n.Parent != nil {
+			unwrap(n)
+		}
+	}
+}
+
+// unwrap replaces n, which must have a parent, with its children.
+func unwrap(n *html.Node) {
+	for c := n.FirstChild; c != nil; c = n.FirstChild {
+		n.RemoveChild(c)
+		n.Parent.InsertBefore(c, n)
+	}
+	n.Parent.RemoveChild(n)
+}
@@ -0,0 +1,189 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"sort"
+
+	"golang.org/x/net/html"
+)
+
+// IsElement reports whether n is a non-nil node of type
+// html.ElementNode.
+func IsElement(n *html.Node) bool {
+	return n != nil && n.Type == html.ElementNode
+}
+
+// IsText reports whether n is a non-nil node of type html.TextNode.
+func IsText(n *html.Node) bool {
+	return n != nil && n.Type == html.TextNode
+}
+
+// IsComment reports whether n is a non-nil node of type
+// html.CommentNode.
+func IsComment(n *html.Node) bool {
+	return n != nil && n.Type == html.CommentNode
+}
+
+// IsDocument reports whether n is a non-nil node of type
+// html.DocumentNode.
+func IsDocument(n *html.Node) bool {
+	return n != nil && n.Type == html.DocumentNode
+}
+
+// IsDoctype reports whether n is a non-nil node of type
+// html.DoctypeNode.
+func IsDoctype(n *html.Node) bool {
+	return n != nil && n.Type == html.DoctypeNode
+}
+
+// IsLeaf reports whether n is a non-nil node with no children.
+func IsLeaf(n *html.Node) bool {
+	return n != nil && n.FirstChild == nil
+}
+
+// ElementNodes returns all nodes of type html.ElementNode in the
+// tree at root, in document order.
+func ElementNodes(root *html.Node) []*html.Node {
+	return FindAll(root, IsElement)
+}
+
+// TextNodes returns all nodes of type html.TextNode in the tree at
+// root, in document order.
+func TextNodes(root *html.Node) []*html.Node {
+	return FindAll(root, IsText)
+}
+
+// CommentNodes returns all nodes of type html.CommentNode in the tree
+// at root, in document order.
+func CommentNodes(root *html.Node) []*html.Node {
+	return FindAll(root, IsComment)
+}
+
+// Unique returns a new slice containing each node of nodes once,
+// in the order of its first occurrence. Nodes are compared by
+// pointer, not by structure.
+func Unique(nodes []*html.Node) []*html.Node {
+	seen := map[*html.Node]struct{}{}
+	result := []*html.Node{}
+	for _, n := range nodes {
+		if _, ok := seen[n]; !ok {
+			seen[n] = struct{}{}
+			result = append(result, n)
+		}
+	}
+	return result
+}
+
+// SortByDocumentOrder sorts nodes in place so that nodes which come
+// earlier in a depth first traversal of the tree at root come first,
+// and returns the sorted slice. Nodes which are not in the tree at
+// root are moved to the end, keeping their relative order.
+func SortByDocumentOrder(nodes []*html.Node, root *html.Node) []*html.Node {
+	rank := NodeIndex(root)
+	sort.SliceStable(nodes, func(i, j int) bool {
+		ri, iok := rank[nodes[i]]
+		rj, jok := rank[nodes[j]]
+		if !iok || !jok {
+			return iok && !jok
+		}
+		return ri < rj
+	})
+	return nodes
+}
+
+// Contains reports whether n is one of the nodes in nodes, comparing
+// by pointer.
+func Contains(nodes []*html.Node, n *html.Node) bool {
+	for _, m := range nodes {
+		if m == n {
+			return true
+		}
+	}
+	return false
+}
+
+// TagName returns the Data field of n if n is a non-nil node of type
+// html.ElementNode, and the empty string otherwise.
+func TagName(n *html.Node) string {
+	if !IsElement(n) {
+		return ""
+	}
+	return n.Data
+}
+
+// voidElements is the set of void elements defined by the HTML
+// Living Standard.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// IsVoidElement reports whether n is an HTML element node (one with
+// an empty Namespace) which is a void element, such as <br> or <img>.
+// Void elements cannot have children.
+func IsVoidElement(n *html.Node) bool {
+	return IsElement(n) && n.Namespace == "" && voidElements[n.Data]
+}
+
+// blockElements and inlineElements classify HTML elements by their
+// usual display type.
+var (
+	blockElements = map[string]bool{
+		"address": true, "article": true, "aside": true,
+		"blockquote": true, "body": true, "dd": true, "details": true,
+		"dialog": true, "div": true, "dl": true, "dt": true,
+		"fieldset": true, "figcaption": true, "figure": true,
+		"footer": true, "form": true, "h1": true, "h2": true, "h3": true,
+		"h4": true, "h5": true, "h6": true, "header": true,
+		"hgroup": true, "hr": true, "html": true, "li": true,
+		"main": true, "nav": true, "ol": true, "p": true, "pre": true,
+		"section": true, "summary": true, "table": true, "tbody": true,
+		"td": true, "tfoot": true, "th": true, "thead": true, "tr": true,
+		"ul": true,
+	}
+	inlineElements = map[string]bool{
+		"a": true, "abbr": true, "b": true, "bdi": true, "bdo": true,
+		"br": true, "cite": true, "code": true, "data": true,
+		"dfn": true, "em": true, "i": true, "img": true, "kbd": true,
+		"label": true, "mark": true, "q": true, "s": true, "samp": true,
+		"small": true, "span": true, "strong": true, "sub": true,
+		"sup": true, "time": true, "u": true, "var": true, "wbr": true,
+	}
+)
+
+// IsBlockElement reports whether n is an HTML element node which is
+// displayed as a block by default, such as <div>, <p>, <h1> or <ul>.
+// Table rows and cells are included.
+func IsBlockElement(n *html.Node) bool {
+	return IsElement(n) && n.Namespace == "" && blockElements[n.Data]
+}
+
+// IsInlineElement reports whether n is an HTML element node which is
+// displayed inline by default, such as <a>, <span>, <em> or
+// <strong>. Elements which are neither block nor inline, such as
+// <script> or <head>, give false for both IsBlockElement and
+// IsInlineElement.
+func IsInlineElement(n *html.Node) bool {
+	return IsElement(n) && n.Namespace == "" && inlineElements[n.Data]
+}
@@ -0,0 +1,64 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import "golang.org/x/net/html"
+
+// SanitizePolicy describes the elements and attributes SanitizeHTML
+// allows to remain in a tree. AllowedTags holds the names of the
+// allowed elements. AllowedAttrs maps an element name to the keys of
+// the attributes allowed on that element.
+type SanitizePolicy struct {
+	AllowedTags  map[string]bool
+	AllowedAttrs map[string][]string
+}
+
+// SanitizeHTML applies policy to the tree below root. Each element
+// whose name is not in policy.AllowedTags is replaced with its
+// children, which are themselves sanitized, and each attribute of the
+// remaining elements not listed for that element in
+// policy.AllowedAttrs is removed. Namespaced attributes are always
+// removed. Since disallowed elements keep their content, the subtrees
+// of elements such as <script> and <style> should first be removed
+// with StripElements if their text is unwanted. Root itself is never
+// unwrapped, but its attributes are sanitized if it is an element.
+func SanitizeHTML(root *html.Node, policy SanitizePolicy) {
+	// Unwrapping an element moves its children but does not otherwise
+	// change them, so each collected element can be visited in turn.
+	for _, n := range ElementNodes(root) {
+		if n != root && n.Parent != nil && !policy.AllowedTags[n.Data] {
+			unwrap(n)
+			continue
+		}
+		allowed := map[string]bool{}
+		for _, k := range policy.AllowedAttrs[n.Data] {
+			allowed[k] = true
+		}
+		attr := n.Attr[:0]
+		for _, a := range n.Attr {
+			if a.Namespace == "" && allowed[a.Key] {
+				attr = append(attr, a)
+			}
+		}
+		n.Attr = attr
+	}
+}
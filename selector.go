@@ -0,0 +1,356 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// SelectFirst returns the first node in a depth first search of root
+// which matches selector, or nil if there is none. The selector takes
+// one of three simple forms: "#id" matches the element whose id
+// attribute is id, ".cls" matches elements for which HasClass(n,cls)
+// is true, and a bare "tag" matches elements with that tag name.
+func SelectFirst(root *html.Node, selector string) *html.Node {
+	match := simpleSelector(selector)
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if match(n) {
+			return n
+		}
+	}
+	return nil
+}
+
+// Select returns all nodes in the tree at root which match selector,
+// in document order. The selector is a comma separated list of
+// selectors in the forms accepted by SelectFirst, and a node is
+// returned if it matches any of them. Since ids should be unique, an
+// "#id" selector contributes at most one node, the first with that
+// id.
+func Select(root *html.Node, selector string) []*html.Node {
+	var matchers []func(*html.Node) bool
+	var ids []string
+	for _, sel := range strings.Split(selector, ",") {
+		sel = strings.TrimSpace(sel)
+		if strings.HasPrefix(sel, "#") {
+			ids = append(ids, sel)
+			continue
+		}
+		matchers = append(matchers, simpleSelector(sel))
+	}
+	for _, id := range ids {
+		if n := SelectFirst(root, id); n != nil {
+			matchers = append(matchers, func(m *html.Node) bool {
+				return m == n
+			})
+		}
+	}
+	return FindAll(root, func(n *html.Node) bool {
+		for _, match := range matchers {
+			if match(n) {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// simpleSelector returns a function reporting whether a node matches
+// the selector sel, in one of the forms accepted by SelectFirst.
+func simpleSelector(sel string) func(*html.Node) bool {
+	sel = strings.TrimSpace(sel)
+	switch {
+	case sel == "":
+		return func(*html.Node) bool { return false }
+	case sel[0] == '#':
+		return func(n *html.Node) bool {
+			id, ok := Attr(n, "id")
+			return ok && id == sel[1:] && n.Type == html.ElementNode
+		}
+	case sel[0] == '.':
+		return func(n *html.Node) bool {
+			return n.Type == html.ElementNode && HasClass(n, sel[1:])
+		}
+	}
+	tag := strings.ToLower(sel)
+	return func(n *html.Node) bool {
+		return n.Type == html.ElementNode && n.Data == tag
+	}
+}
+
+// A Matcher is a compiled CSS selector, as returned by ParseSelector.
+type Matcher struct {
+	groups [][]compound
+}
+
+// compound is a compound selector, such as div.note[title], together
+// with the combinator joining it to the compound on its left.
+type compound struct {
+	comb    byte // ' ' (descendant), '>' (child) or 0 if leftmost
+	tag     string
+	ids     []string
+	classes []string
+	attrs   []attrTest
+}
+
+// attrTest is an attribute selector: [key] or [key=val].
+type attrTest struct {
+	key, val string
+	hasVal   bool
+}
+
+// ParseSelector compiles a CSS selector into a Matcher. A subset of
+// CSS is supported: type selectors (tag, or * for any element), class
+// selectors (.cls), id selectors (#id), attribute selectors ([attr]
+// and [attr=val], with val optionally quoted), the descendant
+// combinator (whitespace), the child combinator (>), and comma
+// separated groups of selectors. It returns an error if css is not of
+// this form.
+func ParseSelector(css string) (*Matcher, error) {
+	p := selectorParser{s: css}
+	m := &Matcher{}
+	for {
+		group, err := p.group()
+		if err != nil {
+			return nil, err
+		}
+		m.groups = append(m.groups, group)
+		if p.i == len(p.s) {
+			return m, nil
+		}
+		p.i++ // skip ','
+	}
+}
+
+// Match reports whether n is an element node matching the selector.
+func (m *Matcher) Match(n *html.Node) bool {
+	for _, g := range m.groups {
+		if matchCompounds(n, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns all nodes in the tree at root which match the
+// selector, in document order.
+func (m *Matcher) Find(root *html.Node) []*html.Node {
+	return FindAll(root, m.Match)
+}
+
+// matchCompounds reports whether n matches the last compound of cs
+// and its ancestors match the rest of cs according to the
+// combinators.
+func matchCompounds(n *html.Node, cs []compound) bool {
+	last := cs[len(cs)-1]
+	if !last.match(n) {
+		return false
+	}
+	if len(cs) == 1 {
+		return true
+	}
+	rest := cs[:len(cs)-1]
+	if last.comb == '>' {
+		return n.Parent != nil && matchCompounds(n.Parent, rest)
+	}
+	for p := n.Parent; p != nil; p = p.Parent {
+		if matchCompounds(p, rest) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether n is an element node matching c, ignoring the
+// combinator.
+func (c compound) match(n *html.Node) bool {
+	if n.Type != html.ElementNode || (c.tag != "" && n.Data != c.tag) {
+		return false
+	}
+	for _, id := range c.ids {
+		if v, ok := Attr(n, "id"); !ok || v != id {
+			return false
+		}
+	}
+	for _, cls := range c.classes {
+		if !HasClass(n, cls) {
+			return false
+		}
+	}
+	for _, a := range c.attrs {
+		if v, ok := Attr(n, a.key); !ok || (a.hasVal && v != a.val) {
+			return false
+		}
+	}
+	return true
+}
+
+// selectorParser holds the state of ParseSelector: the selector s and
+// the offset i of the next byte to be parsed.
+type selectorParser struct {
+	s string
+	i int
+}
+
+// errorf returns an error describing a problem at the current offset.
+func (p *selectorParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("htmlnode: ParseSelector: %s at offset %d in %q",
+		fmt.Sprintf(format, args...), p.i, p.s)
+}
+
+// space skips whitespace, reporting whether there was any.
+func (p *selectorParser) space() bool {
+	start := p.i
+	for p.i < len(p.s) && strings.IndexByte(" \t\n\r\f", p.s[p.i]) >= 0 {
+		p.i++
+	}
+	return p.i > start
+}
+
+// group parses a selector up to the next ',' or the end of input.
+func (p *selectorParser) group() ([]compound, error) {
+	var cs []compound
+	p.space()
+	var comb byte
+	for {
+		c, err := p.compound()
+		if err != nil {
+			return nil, err
+		}
+		if len(cs) > 0 {
+			c.comb = comb
+		}
+		cs = append(cs, c)
+		comb = 0
+		if p.space() {
+			comb = ' '
+		}
+		if p.i == len(p.s) || p.s[p.i] == ',' {
+			return cs, nil
+		}
+		if p.s[p.i] == '>' {
+			comb = '>'
+			p.i++
+			p.space()
+		}
+		if comb == 0 {
+			return nil, p.errorf("unexpected %q", p.s[p.i])
+		}
+	}
+}
+
+// compound parses a compound selector.
+func (p *selectorParser) compound() (compound, error) {
+	var c compound
+	start := p.i
+	if p.i < len(p.s) && p.s[p.i] == '*' {
+		p.i++
+	} else {
+		c.tag = strings.ToLower(p.ident())
+	}
+	for p.i < len(p.s) {
+		switch p.s[p.i] {
+		case '#', '.':
+			kind := p.s[p.i]
+			p.i++
+			name := p.ident()
+			if name == "" {
+				return c, p.errorf("expected name after %q", kind)
+			}
+			if kind == '#' {
+				c.ids = append(c.ids, name)
+			} else {
+				c.classes = append(c.classes, name)
+			}
+		case '[':
+			p.i++
+			p.space()
+			a := attrTest{key: strings.ToLower(p.ident())}
+			if a.key == "" {
+				return c, p.errorf("expected attribute name")
+			}
+			p.space()
+			if p.i < len(p.s) && p.s[p.i] == '=' {
+				p.i++
+				p.space()
+				val, err := p.value()
+				if err != nil {
+					return c, err
+				}
+				a.val, a.hasVal = val, true
+				p.space()
+			}
+			if p.i == len(p.s) || p.s[p.i] != ']' {
+				return c, p.errorf("expected ']'")
+			}
+			p.i++
+			c.attrs = append(c.attrs, a)
+		default:
+			if p.i == start {
+				return c, p.errorf("expected selector")
+			}
+			return c, nil
+		}
+	}
+	if p.i == start {
+		return c, p.errorf("expected selector")
+	}
+	return c, nil
+}
+
+// ident parses a name made of letters, digits, '-', '_' and non-ASCII
+// bytes, returning the empty string if there is none.
+func (p *selectorParser) ident() string {
+	start := p.i
+	for p.i < len(p.s) {
+		b := p.s[p.i]
+		if b != '-' && b != '_' && b < 0x80 &&
+			(b < '0' || b > '9') && (b < 'a' || b > 'z') &&
+			(b < 'A' || b > 'Z') {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// value parses an attribute value, either quoted or a name.
+func (p *selectorParser) value() (string, error) {
+	if p.i < len(p.s) && (p.s[p.i] == '"' || p.s[p.i] == '\'') {
+		q := p.s[p.i]
+		end := strings.IndexByte(p.s[p.i+1:], q)
+		if end < 0 {
+			return "", p.errorf("unterminated string")
+		}
+		v := p.s[p.i+1 : p.i+1+end]
+		p.i += end + 2
+		return v, nil
+	}
+	v := p.ident()
+	if v == "" {
+		return "", p.errorf("expected attribute value")
+	}
+	return v, nil
+}
@@ -0,0 +1,493 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+)
+
+// InnerText returns an approximation of the text a browser would give
+// for the innerText property of root. Unlike Flatten it lays the text
+// out: runs of whitespace are collapsed to a single space, except
+// within <pre> elements; line breaks are placed at the boundaries of
+// block elements (see IsBlockElement), with a blank line around <p>
+// elements, and at each <br>; leading and trailing whitespace is
+// removed from the result, even where it comes from a <pre> element.
+// The contents of <script>, <style>, <template> and <head> elements
+// below root are skipped, as are comments.
+func InnerText(root *html.Node) string {
+	var t textLayout
+	var walk func(n *html.Node, pre bool)
+	walk = func(n *html.Node, pre bool) {
+		switch n.Type {
+		case html.TextNode:
+			t.text(n.Data, pre)
+			return
+		case html.ElementNode:
+		case html.DocumentNode:
+		default:
+			return
+		}
+		if n != root && n.Type == html.ElementNode && n.Namespace == "" {
+			switch n.Data {
+			case "script", "style", "template", "head":
+				return
+			case "br":
+				t.lineBreak()
+				return
+			case "pre":
+				pre = true
+			}
+		}
+		breaks := 0
+		if IsBlockElement(n) {
+			breaks = 1
+			if n.Data == "p" {
+				breaks = 2
+			}
+		}
+		t.blockBreak(breaks)
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c, pre)
+		}
+		t.blockBreak(breaks)
+	}
+	if root != nil {
+		walk(root, false)
+	}
+	// Text within <pre> is written as is, so may still need trimming.
+	return strings.TrimSpace(t.b.String())
+}
+
+// textLayout accumulates text for InnerText, deferring separators
+// until the next piece of text is written so that none are left at
+// the start or end.
+type textLayout struct {
+	b      strings.Builder
+	breaks int  // line breaks due before the next text
+	space  bool // a space is due before the next text
+}
+
+// write writes s to t.b, preceded by any separator which is due.
+func (t *textLayout) write(s string) {
+	if t.b.Len() > 0 {
+		if t.breaks > 0 {
+			t.b.WriteString(strings.Repeat("\n", t.breaks))
+		} else if t.space {
+			t.b.WriteString(" ")
+		}
+	}
+	t.breaks, t.space = 0, false
+	t.b.WriteString(s)
+}
+
+// text lays out the data of a text node. If pre is false whitespace
+// is collapsed.
+func (t *textLayout) text(s string, pre bool) {
+	if pre {
+		if s != "" {
+			t.write(s)
+		}
+		return
+	}
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		t.space = t.space || s != ""
+		return
+	}
+	if strings.TrimLeft(s, " \t\n\f\r") != s {
+		t.space = true
+	}
+	t.write(strings.Join(words, " "))
+	if strings.TrimRight(s, " \t\n\f\r") != s {
+		t.space = true
+	}
+}
+
+// blockBreak requests at least n line breaks before the next text.
+func (t *textLayout) blockBreak(n int) {
+	if n > t.breaks {
+		t.breaks = n
+	}
+}
+
+// lineBreak requests an additional line break before the next text.
+func (t *textLayout) lineBreak() {
+	t.breaks++
+}
+
+// PrintMarkdown writes the tree at root to w as Markdown. Headings,
+// paragraphs, nested <ul> and <ol> lists, block quotes, <pre> code
+// blocks (with a language taken from a "language-" class on an inner
+// <code>), horizontal rules, links, images with their alt text,
+// emphasis, strong emphasis, inline code and line breaks are
+// converted to their Markdown equivalents. Other block elements are
+// treated as plain paragraphs and other inline elements contribute
+// just their text. Whitespace in text is collapsed. So that text is
+// not taken as Markdown or HTML, the characters \ ` * _ [ ] in it are
+// escaped with a backslash, < > and & are written as character
+// references, and a #, -, +, >, = or "1." which would begin a line is
+// escaped. Link and image URLs are written between angle brackets.
+// The contents of <script>, <style>, <template> and <head> elements
+// are skipped, as are comments.
+//
+// PrintMarkdown returns any error it gets when writing to w.
+func PrintMarkdown(w io.Writer, root *html.Node) error {
+	return printBlocks(w, root, true)
+}
+
+// PrintPlainText writes the tree at root to w as plain text, laid out
+// in the manner of a text mode browser. Block elements are separated
+// by blank lines, <h1> headings are underlined with "=" and other
+// headings with "-", items of <ul> lists are prefixed with "*" and
+// items of <ol> lists with their number, block quotes are indented,
+// and the contents of <pre> elements are kept as they are. Inline
+// formatting is dropped, leaving just the text, with images replaced
+// by their alt text. Otherwise it treats the tree as PrintMarkdown
+// does.
+//
+// PrintPlainText returns any error it gets when writing to w.
+func PrintPlainText(w io.Writer, root *html.Node) error {
+	return printBlocks(w, root, false)
+}
+
+// printBlocks implements PrintMarkdown if markdown is true and
+// PrintPlainText otherwise.
+func printBlocks(w io.Writer, root *html.Node, markdown bool) error {
+	if root == nil {
+		return nil
+	}
+	bt := blockText{markdown: markdown}
+	bt.node(root)
+	s := bt.String("\n\n")
+	if s == "" {
+		return nil
+	}
+	_, err := io.WriteString(w, s+"\n")
+	return err
+}
+
+// blockText accumulates the text for a sequence of nodes, as Markdown
+// or as plain text, as a list of blocks together with the inline
+// content of the block currently being built.
+type blockText struct {
+	markdown bool
+	blocks   []string
+	inline   strings.Builder
+}
+
+// String flushes any inline content and returns the blocks joined by
+// sep.
+func (bt *blockText) String(sep string) string {
+	bt.flush()
+	return strings.Join(bt.blocks, sep)
+}
+
+// flush ends the current block of inline content.
+func (bt *blockText) flush() {
+	s := strings.TrimSpace(bt.inline.String())
+	bt.inline.Reset()
+	if bt.markdown {
+		s = escapeLineStarts(s)
+	}
+	if s != "" {
+		bt.blocks = append(bt.blocks, s)
+	}
+}
+
+// block flushes any inline content and then appends s as a block,
+// unless it is empty.
+func (bt *blockText) block(s string) {
+	bt.flush()
+	if s != "" {
+		bt.blocks = append(bt.blocks, s)
+	}
+}
+
+// of returns the text for the children of n, in the same mode as bt,
+// with blocks joined by sep.
+func (bt *blockText) of(n *html.Node, sep string) string {
+	sub := blockText{markdown: bt.markdown}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sub.node(c)
+	}
+	return sub.String(sep)
+}
+
+// inlineOf returns the text for the children of n on a single line.
+func (bt *blockText) inlineOf(n *html.Node) string {
+	return strings.Join(strings.Fields(bt.of(n, " ")), " ")
+}
+
+// escape escapes the characters in s which have a meaning in
+// Markdown, if bt is producing Markdown.
+func (bt *blockText) escape(s string) string {
+	if !bt.markdown {
+		return s
+	}
+	return markdownEscaper.Replace(s)
+}
+
+// markdownEscaper escapes characters with a meaning in Markdown, or
+// in the HTML which Markdown may contain.
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `&lt;`, `>`, `&gt;`, `&`, `&amp;`)
+
+// escapeLineStarts escapes the first character of each line of s
+// which would otherwise begin a Markdown heading, list item, block
+// quote or heading underline.
+func escapeLineStarts(s string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		k := len(l) - len(strings.TrimLeft(l, " "))
+		rest := l[k:]
+		if rest == "" {
+			continue
+		}
+		if strings.IndexByte("#-+>=", rest[0]) >= 0 {
+			lines[i] = l[:k] + `\` + rest
+			continue
+		}
+		j := 0
+		for j < len(rest) && rest[j] >= '0' && rest[j] <= '9' {
+			j++
+		}
+		if j > 0 && j < len(rest) && (rest[j] == '.' || rest[j] == ')') {
+			lines[i] = l[:k+j] + `\` + rest[j:]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// markdownURL returns u as a Markdown link destination, between angle
+// brackets so that spaces and parentheses in u need no escaping.
+func markdownURL(u string) string {
+	u = strings.NewReplacer(`\`, `\\`, `<`, `\<`, `>`, `\>`,
+		"\n", "%0A", "\r", "%0D").Replace(u)
+	return "<" + u + ">"
+}
+
+// prefixLines returns s with each line prefixed by prefix, and any
+// trailing spaces removed from the result.
+func prefixLines(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(prefix+l, " ")
+	}
+	return strings.Join(lines, "\n")
+}
+
+// node adds the text for n to bt.
+func (bt *blockText) node(n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		bt.inline.WriteString(bt.escape(collapseSpace(n.Data)))
+		return
+	case html.DocumentNode:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			bt.node(c)
+		}
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	if n.Namespace != "" {
+		return
+	}
+	switch n.Data {
+	case "script", "style", "template", "head":
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		s := bt.inlineOf(n)
+		switch {
+		case s == "":
+		case bt.markdown:
+			bt.block(strings.Repeat("#", int(n.Data[1]-'0')) + " " + s)
+		default:
+			underline := "-"
+			if n.Data == "h1" {
+				underline = "="
+			}
+			bt.block(s + "\n" +
+				strings.Repeat(underline, utf8.RuneCountInString(s)))
+		}
+	case "ul", "ol":
+		bt.block(bt.list(n))
+	case "blockquote":
+		if s := bt.of(n, "\n\n"); s != "" {
+			if bt.markdown {
+				bt.block(prefixLines(s, "> "))
+			} else {
+				bt.block(prefixLines(s, "    "))
+			}
+		}
+	case "pre":
+		code := strings.TrimSuffix(Flatten(n), "\n")
+		if !bt.markdown {
+			bt.block(code)
+			return
+		}
+		var lang string
+		if c := n.FirstChild; c != nil && c.Type == html.ElementNode &&
+			c.Data == "code" {
+			cls, _ := Attr(c, "class")
+			for _, f := range strings.Fields(cls) {
+				if strings.HasPrefix(f, "language-") {
+					lang = f[len("language-"):]
+					break
+				}
+			}
+		}
+		fence := "```"
+		if strings.Contains(code, fence) {
+			fence = "~~~"
+		}
+		bt.block(fence + lang + "\n" + code + "\n" + fence)
+	case "hr":
+		if bt.markdown {
+			bt.block("---")
+		} else {
+			bt.block(strings.Repeat("-", 40))
+		}
+	case "br":
+		if bt.markdown {
+			bt.inline.WriteString("  ")
+		}
+		bt.inline.WriteString("\n")
+	case "code", "kbd", "samp":
+		code := collapseSpace(Flatten(n))
+		switch {
+		case strings.TrimSpace(code) == "":
+		case !bt.markdown:
+			bt.inline.WriteString(code)
+		case strings.Contains(code, "`"):
+			bt.inline.WriteString("`` " + code + " ``")
+		default:
+			bt.inline.WriteString("`" + code + "`")
+		}
+	case "em", "i":
+		bt.emphasis(n, "*")
+	case "strong", "b":
+		bt.emphasis(n, "**")
+	case "a":
+		text := bt.inlineOf(n)
+		href, ok := Attr(n, "href")
+		switch {
+		case !ok || !bt.markdown:
+			bt.inline.WriteString(text)
+		case text == "":
+			bt.inline.WriteString(
+				"[" + bt.escape(href) + "](" + markdownURL(href) + ")")
+		default:
+			bt.inline.WriteString("[" + text + "](" + markdownURL(href) + ")")
+		}
+	case "img":
+		src, _ := Attr(n, "src")
+		alt, _ := Attr(n, "alt")
+		if bt.markdown {
+			bt.inline.WriteString(
+				"![" + bt.escape(alt) + "](" + markdownURL(src) + ")")
+		} else {
+			bt.inline.WriteString(alt)
+		}
+	default:
+		if IsBlockElement(n) {
+			bt.block(bt.of(n, "\n\n"))
+			return
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			bt.node(c)
+		}
+	}
+}
+
+// emphasis adds the inline text for n to bt, surrounded by delim if
+// bt is producing Markdown.
+func (bt *blockText) emphasis(n *html.Node, delim string) {
+	s := bt.inlineOf(n)
+	if s != "" && bt.markdown {
+		s = delim + s + delim
+	}
+	bt.inline.WriteString(s)
+}
+
+// list returns the text for the <ul> or <ol> element list. The lines
+// of each item after the first are indented to line up with the text
+// following the item's marker, so that nested lists and paragraphs
+// belong to the item.
+func (bt *blockText) list(list *html.Node) string {
+	var items []string
+	i := 1
+	if start, ok := Attr(list, "start"); ok && list.Data == "ol" {
+		fmt.Sscan(start, &i)
+	}
+	bullet := "* "
+	if bt.markdown {
+		bullet = "- "
+	}
+	for li := list.FirstChild; li != nil; li = li.NextSibling {
+		if li.Type != html.ElementNode || li.Data != "li" {
+			continue
+		}
+		marker := bullet
+		if list.Data == "ol" {
+			marker = fmt.Sprintf("%d. ", i)
+			i++
+		}
+		lines := strings.Split(bt.of(li, "\n"), "\n")
+		for j := 1; j < len(lines); j++ {
+			if lines[j] != "" {
+				lines[j] = strings.Repeat(" ", len(marker)) + lines[j]
+			}
+		}
+		items = append(items, marker+strings.Join(lines, "\n"))
+	}
+	return strings.Join(items, "\n")
+}
+
+// collapseSpace replaces each run of whitespace in s with a single
+// space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	space := false
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\f' || r == '\r' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(r)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
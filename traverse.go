@@ -0,0 +1,180 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/net/html"
+)
+
+// ErrStopped is returned by WalkContext when the walk is stopped by
+// its callback.
+var ErrStopped = errors.New("htmlnode: walk stopped by callback")
+
+// WalkContext calls fn for each node in a depth first traversal of
+// the tree at root, passing the node and its depth below root (root
+// itself having depth 0). Before visiting each node it checks whether
+// ctx is done, and if so returns ctx.Err(). If fn returns false the
+// walk stops and WalkContext returns ErrStopped. If the whole tree is
+// walked WalkContext returns nil.
+func WalkContext(ctx context.Context, root *html.Node,
+	fn func(*html.Node, int) bool) error {
+	depth := 0
+	for n := root; n != nil; {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if !fn(n, depth) {
+			return ErrStopped
+		}
+		var delta int
+		n, delta = Next(n, root)
+		depth += delta
+	}
+	return nil
+}
+
+// NodePath returns the path from the root of n's tree down to n: the
+// slice [root, ..., n.Parent, n]. If n is nil it returns nil.
+func NodePath(n *html.Node) []*html.Node {
+	var path []*html.Node
+	for ; n != nil; n = n.Parent {
+		path = append(path, n)
+	}
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// VisitElements calls fn for each node of type html.ElementNode in a
+// depth first traversal of the tree at root, skipping nodes of other
+// types. If fn returns false the traversal stops.
+func VisitElements(root *html.Node, fn func(n *html.Node) bool) {
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type == html.ElementNode && !fn(n) {
+			return
+		}
+	}
+}
+
+// DocumentRoot returns the root of the tree containing n, found by
+// following Parent until reaching a node whose Parent is nil. It
+// returns n itself if n.Parent is nil, and nil if n is nil.
+func DocumentRoot(n *html.Node) *html.Node {
+	for n != nil && n.Parent != nil {
+		n = n.Parent
+	}
+	return n
+}
+
+// AnnotateDepths returns a map from each node in the tree at root to
+// its depth below root, root itself having depth 0.
+func AnnotateDepths(root *html.Node) map[*html.Node]int {
+	depths := map[*html.Node]int{}
+	depth := 0
+	for n := root; n != nil; {
+		depths[n] = depth
+		var delta int
+		n, delta = Next(n, root)
+		depth += delta
+	}
+	return depths
+}
+
+// NodeIndex returns a map from each node in the tree at root to its
+// position in a depth first traversal of the tree, starting with 0
+// for root. Comparing positions gives the document order of nodes.
+func NodeIndex(root *html.Node) map[*html.Node]int {
+	index := map[*html.Node]int{}
+	for n := root; n != nil; n, _ = Next(n, root) {
+		index[n] = len(index)
+	}
+	return index
+}
+
+// TraversalPath returns the deltas returned by the successive calls to
+// Next needed to reach target from root in a depth first traversal
+// of the tree at root. The path of root itself is empty. Since it
+// contains no pointers, a path can be stored and replayed with NodeAt
+// on a structurally identical tree, such as a re-parsed copy of the
+// same document. TraversalPath returns an error if target is not in
+// the tree at root.
+func TraversalPath(root, target *html.Node) ([]int, error) {
+	path := []int{}
+	for n := root; n != nil; {
+		if n == target {
+			return path, nil
+		}
+		var delta int
+		n, delta = Next(n, root)
+		path = append(path, delta)
+	}
+	return nil, errors.New("htmlnode: TraversalPath: target not in tree")
+}
+
+// NodeAt replays a path returned by TraversalPath, starting at root
+// and calling Next once for each delta in path, and returns the node
+// reached. It returns an error if the traversal ends before the path
+// does, or if a call to Next returns a delta which differs from the
+// one in path, meaning that the tree does not have the structure the
+// path was made for.
+func NodeAt(root *html.Node, path []int) (*html.Node, error) {
+	n := root
+	if n == nil {
+		return nil, errors.New("htmlnode: NodeAt: nil root")
+	}
+	for i, want := range path {
+		var delta int
+		n, delta = Next(n, root)
+		if n == nil {
+			return nil, fmt.Errorf(
+				"htmlnode: NodeAt: path leaves the tree at step %d", i)
+		}
+		if delta != want {
+			return nil, fmt.Errorf(
+				"htmlnode: NodeAt: delta %d at step %d, path has %d",
+				delta, i, want)
+		}
+	}
+	return n, nil
+}
+
+// DepthFirstSlice returns all nodes in the tree at root in the order
+// of a depth first traversal, root first. The slice is allocated at
+// its full size up front, after a first pass to count the nodes.
+func DepthFirstSlice(root *html.Node) []*html.Node {
+	count := 0
+	for n := root; n != nil; n, _ = Next(n, root) {
+		count++
+	}
+	result := make([]*html.Node, 0, count)
+	for n := root; n != nil; n, _ = Next(n, root) {
+		result = append(result, n)
+	}
+	return result
+}
@@ -0,0 +1,195 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// urlAttrs holds the keys of the attributes whose values are single
+// URLs.
+var urlAttrs = map[string]bool{
+	"action":     true,
+	"background": true,
+	"cite":       true,
+	"codebase":   true,
+	"data":       true,
+	"formaction": true,
+	"href":       true,
+	"icon":       true,
+	"longdesc":   true,
+	"manifest":   true,
+	"poster":     true,
+	"src":        true,
+}
+
+// RewriteURLs calls fn for each URL-bearing attribute of each element
+// in the tree at root, passing the element name, the attribute key and
+// the attribute value, and replaces the value with the result. The
+// attributes rewritten are those whose value is a single URL: href,
+// src, action, formaction, poster, data, cite, background, codebase,
+// icon, longdesc and manifest, without a namespace.
+func RewriteURLs(root *html.Node, fn func(tag, attr, url string) string) {
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		for i, a := range n.Attr {
+			if a.Namespace == "" && urlAttrs[a.Key] {
+				n.Attr[i].Val = fn(n.Data, a.Key, a.Val)
+			}
+		}
+	}
+}
+
+// AbsoluteURLs resolves the URLs in the tree at root against base,
+// replacing each relative URL with an absolute one. The attributes
+// rewritten are those handled by RewriteURLs, along with the URLs
+// listed in srcset attributes. Values which do not parse as URLs are
+// left unchanged.
+func AbsoluteURLs(root *html.Node, base *url.URL) {
+	resolve := func(s string) string {
+		u, err := url.Parse(strings.TrimSpace(s))
+		if err != nil {
+			return s
+		}
+		return base.ResolveReference(u).String()
+	}
+	RewriteURLs(root, func(_, _, s string) string {
+		return resolve(s)
+	})
+	for n := root; n != nil; n, _ = Next(n, root) {
+		if n.Type != html.ElementNode {
+			continue
+		}
+		for i, a := range n.Attr {
+			if a.Namespace != "" || a.Key != "srcset" {
+				continue
+			}
+			cs := srcsetCandidates(a.Val)
+			parts := make([]string, len(cs))
+			for j, c := range cs {
+				parts[j] = resolve(c.url)
+				if c.descriptor != "" {
+					parts[j] += " " + c.descriptor
+				}
+			}
+			n.Attr[i].Val = strings.Join(parts, ", ")
+		}
+	}
+}
+
+// SrcsetEntry is an image candidate from a srcset attribute. Width is
+// the value of a w descriptor, or zero if there is none, and Density
+// that of an x descriptor, defaulting as described for ParseSrcset.
+type SrcsetEntry struct {
+	URL     string
+	Width   int
+	Density float64
+}
+
+// ParseSrcset parses the value of a srcset attribute into its image
+// candidates, in order. Candidates are separated by commas, and within
+// a candidate the URL is separated from its descriptors by
+// whitespace. A comma inside a URL does not separate candidates unless
+// it ends the URL. A candidate without a w or x descriptor is given a
+// Density of 1, as the HTML standard specifies. Descriptors which are
+// not recognised or do not parse are ignored.
+func ParseSrcset(srcset string) []SrcsetEntry {
+	var es []SrcsetEntry
+	for _, c := range srcsetCandidates(srcset) {
+		e := SrcsetEntry{URL: c.url}
+		for _, d := range strings.Fields(c.descriptor) {
+			switch v := d[:len(d)-1]; d[len(d)-1] {
+			case 'w':
+				if w, err := strconv.Atoi(v); err == nil && w > 0 {
+					e.Width = w
+				}
+			case 'x':
+				if x, err := strconv.ParseFloat(v, 64); err == nil && x > 0 {
+					e.Density = x
+				}
+			}
+		}
+		if e.Width == 0 && e.Density == 0 {
+			e.Density = 1
+		}
+		es = append(es, e)
+	}
+	return es
+}
+
+// srcsetCandidate is an image candidate string from a srcset
+// attribute, split into its URL and its (possibly empty) descriptor.
+type srcsetCandidate struct {
+	url, descriptor string
+}
+
+// srcsetCandidates splits the value of a srcset attribute into image
+// candidates, following the parsing rules of the HTML standard: a URL
+// runs up to the next whitespace, and is followed by a descriptor
+// running up to the next comma outside parentheses. A comma ending a
+// URL ends the candidate, so URLs may contain commas elsewhere.
+func srcsetCandidates(s string) []srcsetCandidate {
+	var cs []srcsetCandidate
+	for {
+		s = strings.TrimLeft(s, " \t\n\f\r,")
+		if s == "" {
+			return cs
+		}
+		i := strings.IndexAny(s, " \t\n\f\r")
+		if i < 0 {
+			i = len(s)
+		}
+		c := srcsetCandidate{url: s[:i]}
+		s = s[i:]
+		if strings.HasSuffix(c.url, ",") {
+			c.url = strings.TrimRight(c.url, ",")
+			cs = append(cs, c)
+			continue
+		}
+		depth := 0
+		i = strings.IndexFunc(s, func(r rune) bool {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				if depth > 0 {
+					depth--
+				}
+			case ',':
+				return depth == 0
+			}
+			return false
+		})
+		if i < 0 {
+			i = len(s)
+		}
+		c.descriptor = strings.Join(strings.Fields(s[:i]), " ")
+		s = s[i:]
+		cs = append(cs, c)
+	}
+}
@@ -0,0 +1,298 @@
+/*
+   Copyright 2026 The Htmlnode Authors. See the AUTHORS file at the
+   top-level directory of this distribution and at
+   <https://xi2.org/x/htmlnode/m/AUTHORS>.
+
+   This file is part of Htmlnode.
+
+   Htmlnode is free software: you can redistribute it and/or modify it
+   under the terms of the GNU General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   Htmlnode is distributed in the hope that it will be useful, but
+   WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+   General Public License for more details.
+
+   You should have received a copy of the GNU General Public License
+   along with Htmlnode.  If not, see <https://www.gnu.org/licenses/>.
+*/
+
+package htmlnode
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// XPath evaluates the XPath expression expr with root as the context
+// node and returns the resulting node set in document order. Only a
+// subset of XPath 1.0 is supported: location paths made of steps
+// separated by / or //, where a step is an element name, * (any
+// element), text() (text nodes), node() (any node), . (the context
+// node) or .. (its parent). A leading / or // starts the path at
+// root, which is treated as the document node. Each step may be
+// followed by predicates of the forms [n] (the n-th node selected
+// from each context node, counting from 1), [@attr], [@attr='val']
+// and [text()='val'] (an element with a text node child whose Data
+// is val). XPath returns an error if expr is not of this form.
+func XPath(root *html.Node, expr string) ([]*html.Node, error) {
+	steps, err := parseXPath(expr)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, nil
+	}
+	ctx := []*html.Node{root}
+	for _, s := range steps {
+		var next []*html.Node
+		for _, c := range ctx {
+			bases := []*html.Node{c}
+			if s.desc {
+				bases = FindAll(c, func(*html.Node) bool { return true })
+			}
+			for _, b := range bases {
+				next = append(next, s.eval(b)...)
+			}
+		}
+		ctx = Unique(next)
+	}
+	return SortByDocumentOrder(ctx, DocumentRoot(root)), nil
+}
+
+// EvalXPathString evaluates expr as XPath does and returns the string
+// value of the first node in the result: its Flatten text for element
+// and document nodes, and its Data field for other nodes. If the
+// result is empty it returns the empty string.
+func EvalXPathString(root *html.Node, expr string) (string, error) {
+	ns, err := XPath(root, expr)
+	if err != nil || len(ns) == 0 {
+		return "", err
+	}
+	switch n := ns[0]; n.Type {
+	case html.ElementNode, html.DocumentNode:
+		return Flatten(n), nil
+	default:
+		return n.Data, nil
+	}
+}
+
+// xpathStep is a single step of a location path.
+type xpathStep struct {
+	desc  bool   // preceded by //
+	test  string // ".", "..", "*", "text()", "node()" or a name
+	preds []xpathPred
+}
+
+// xpathPred is a predicate of a step. If pos is non-zero it is a
+// positional predicate; otherwise it tests attribute key (or the text
+// children if key is empty) for existence or, if hasVal is set, for
+// the value val.
+type xpathPred struct {
+	pos    int
+	key    string
+	val    string
+	hasVal bool
+}
+
+// eval returns the nodes selected by s from the context node n.
+func (s xpathStep) eval(n *html.Node) []*html.Node {
+	var ns []*html.Node
+	switch s.test {
+	case ".":
+		ns = []*html.Node{n}
+	case "..":
+		if n.Parent != nil {
+			ns = []*html.Node{n.Parent}
+		}
+	default:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			switch {
+			case s.test == "node()",
+				s.test == "text()" && c.Type == html.TextNode,
+				s.test == "*" && c.Type == html.ElementNode,
+				c.Type == html.ElementNode && c.Data == s.test:
+				ns = append(ns, c)
+			}
+		}
+	}
+	for _, p := range s.preds {
+		var kept []*html.Node
+		for i, m := range ns {
+			if p.match(m, i+1) {
+				kept = append(kept, m)
+			}
+		}
+		ns = kept
+	}
+	return ns
+}
+
+// match reports whether node n, at position pos among the nodes being
+// filtered, satisfies p.
+func (p xpathPred) match(n *html.Node, pos int) bool {
+	if p.pos != 0 {
+		return pos == p.pos
+	}
+	if p.key != "" {
+		v, ok := Attr(n, p.key)
+		return ok && (!p.hasVal || v == p.val)
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type == html.TextNode && (!p.hasVal || c.Data == p.val) {
+			return true
+		}
+	}
+	return false
+}
+
+// xpathParser holds the state of parseXPath: the expression s and the
+// offset i of the next byte to be parsed.
+type xpathParser struct {
+	s string
+	i int
+}
+
+// errorf returns an error describing a problem at the current offset.
+func (p *xpathParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("htmlnode: XPath: %s at offset %d in %q",
+		fmt.Sprintf(format, args...), p.i, p.s)
+}
+
+// space skips whitespace.
+func (p *xpathParser) space() {
+	for p.i < len(p.s) && strings.IndexByte(" \t\n\r", p.s[p.i]) >= 0 {
+		p.i++
+	}
+}
+
+// accept skips tok and reports true if it comes next in the input.
+func (p *xpathParser) accept(tok string) bool {
+	p.space()
+	if strings.HasPrefix(p.s[p.i:], tok) {
+		p.i += len(tok)
+		return true
+	}
+	return false
+}
+
+// name parses an XML name, returning the empty string if there is
+// none.
+func (p *xpathParser) name() string {
+	p.space()
+	start := p.i
+	for p.i < len(p.s) {
+		b := p.s[p.i]
+		if b != '-' && b != '_' && b != '.' && b < 0x80 &&
+			(b < '0' || b > '9' || p.i == start) &&
+			(b < 'a' || b > 'z') && (b < 'A' || b > 'Z') {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i]
+}
+
+// parseXPath parses expr into a list of steps.
+func parseXPath(expr string) ([]xpathStep, error) {
+	p := &xpathParser{s: expr}
+	var steps []xpathStep
+	desc := p.accept("//")
+	if !desc && p.accept("/") {
+		if p.space(); p.i == len(p.s) {
+			return []xpathStep{{test: "."}}, nil
+		}
+	}
+	for {
+		s, err := p.step()
+		if err != nil {
+			return nil, err
+		}
+		s.desc = desc
+		steps = append(steps, s)
+		if p.space(); p.i == len(p.s) {
+			return steps, nil
+		}
+		if desc = p.accept("//"); !desc && !p.accept("/") {
+			return nil, p.errorf("unexpected %q", p.s[p.i])
+		}
+	}
+}
+
+// step parses a single step and its predicates.
+func (p *xpathParser) step() (xpathStep, error) {
+	var s xpathStep
+	switch {
+	case p.accept(".."):
+		s.test = ".."
+	case p.accept("."):
+		s.test = "."
+	case p.accept("*"):
+		s.test = "*"
+	case p.accept("text()"):
+		s.test = "text()"
+	case p.accept("node()"):
+		s.test = "node()"
+	default:
+		if s.test = strings.ToLower(p.name()); s.test == "" {
+			return s, p.errorf("expected step")
+		}
+	}
+	for p.accept("[") {
+		pred, err := p.predicate()
+		if err != nil {
+			return s, err
+		}
+		if !p.accept("]") {
+			return s, p.errorf("expected ']'")
+		}
+		s.preds = append(s.preds, pred)
+	}
+	return s, nil
+}
+
+// predicate parses the contents of a predicate.
+func (p *xpathParser) predicate() (xpathPred, error) {
+	var pred xpathPred
+	p.space()
+	switch {
+	case p.i < len(p.s) && p.s[p.i] >= '0' && p.s[p.i] <= '9':
+		start := p.i
+		for p.i < len(p.s) && p.s[p.i] >= '0' && p.s[p.i] <= '9' {
+			p.i++
+		}
+		pos, err := strconv.Atoi(p.s[start:p.i])
+		if err != nil || pos == 0 {
+			p.i = start
+			return pred, p.errorf("invalid position")
+		}
+		pred.pos = pos
+		return pred, nil
+	case p.accept("@"):
+		if pred.key = p.name(); pred.key == "" {
+			return pred, p.errorf("expected attribute name")
+		}
+	case p.accept("text()"):
+	default:
+		return pred, p.errorf("unsupported predicate")
+	}
+	if p.accept("=") {
+		p.space()
+		if p.i == len(p.s) || (p.s[p.i] != '"' && p.s[p.i] != '\'') {
+			return pred, p.errorf("expected string literal")
+		}
+		q := p.s[p.i]
+		end := strings.IndexByte(p.s[p.i+1:], q)
+		if end < 0 {
+			return pred, p.errorf("unterminated string literal")
+		}
+		pred.val, pred.hasVal = p.s[p.i+1:p.i+1+end], true
+		p.i += end + 2
+	}
+	return pred, nil
+}